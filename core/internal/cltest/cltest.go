@@ -465,6 +465,27 @@ func MustReadFile(t testing.TB, file string) []byte {
 	return content
 }
 
+// CreateCredsFile writes an API credentials file for user with the default
+// Password, returning its path and a function which removes it.
+func CreateCredsFile(t testing.TB, user string) (string, func()) {
+	return CreateCredsFileWithPassword(t, user, Password)
+}
+
+// CreateCredsFileWithPassword writes an API credentials file for user and
+// password, returning its path and a function which removes it.
+func CreateCredsFileWithPassword(t testing.TB, user, password string) (string, func()) {
+	t.Helper()
+
+	file, err := ioutil.TempFile("", "apicredentials")
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString(user + "\n" + password)
+	require.NoError(t, err)
+	return file.Name(), func() {
+		assert.NoError(t, os.Remove(file.Name()))
+	}
+}
+
 type HTTPClientCleaner struct {
 	HTTPClient cmd.HTTPClient
 	t          testing.TB
@@ -0,0 +1,35 @@
+package cltest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCredsFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		create   func() (string, func())
+		expected string
+	}{
+		{"default password", func() (string, func()) { return CreateCredsFile(t, "alice@test.net") },
+			"alice@test.net\n" + Password},
+		{"custom password", func() (string, func()) { return CreateCredsFileWithPassword(t, "bob@test.net", "hunter2") },
+			"bob@test.net\nhunter2"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path, cleanup := test.create()
+			content, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, string(content))
+
+			cleanup()
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/assets"
 	"github.com/smartcontractkit/chainlink/core/eth"
 	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
 	"github.com/smartcontractkit/chainlink/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/core/store"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
@@ -174,6 +175,17 @@ func NewJobWithFluxMonitorInitiatorWithBridge() models.JobSpec {
 	return j
 }
 
+// NewFluxMonitorJobSpec creates a new Job with a FluxMonitor initiator which
+// reports according to triggers
+func NewFluxMonitorJobSpec(t *testing.T, triggers triggerfns.TriggerFns) models.JobSpec {
+	value, err := triggers.Value()
+	require.NoError(t, err)
+
+	j := NewJobWithFluxMonitorInitiator()
+	j.Initiators[0].TriggerFns = JSONFromBytes(t, value.([]byte))
+	return j
+}
+
 // NewTx returns a Tx using a specified from address and sentAt
 func NewTx(from common.Address, sentAt uint64) *models.Tx {
 	tx := &models.Tx{
@@ -363,6 +375,26 @@ func MustJSONSet(t *testing.T, json, path string, value interface{}) string {
 	return json
 }
 
+// MustHelloWorldAgreement reads testdata/hello_world_agreement.json from the
+// calling package, whitelisting only oracle
+func MustHelloWorldAgreement(t *testing.T, oracle common.Address) string {
+	return MustHelloWorldAgreementWithOracles(t, oracle)
+}
+
+// MustHelloWorldAgreementWithOracles reads testdata/hello_world_agreement.json
+// from the calling package, whitelisting oracles in the order given
+func MustHelloWorldAgreementWithOracles(t *testing.T, oracles ...common.Address) string {
+	t.Helper()
+	require.NotEmpty(t, oracles, "a service agreement needs at least one oracle")
+
+	hexes := make([]string, len(oracles))
+	for i, oracle := range oracles {
+		hexes[i] = oracle.Hex()
+	}
+	agreement := string(MustReadFile(t, "testdata/hello_world_agreement.json"))
+	return MustJSONSet(t, agreement, "oracles", hexes)
+}
+
 // MustJSONDel uses sjson.Delete to remove a path from a JSON string and returns the string
 func MustJSONDel(t *testing.T, json, path string) string {
 	json, err := sjson.Delete(json, path)
@@ -4,7 +4,13 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 )
 
 func i(x int64) *big.Int { return big.NewInt(x) }
@@ -15,3 +21,44 @@ func TestBigHexInt(t *testing.T) {
 	newBig := BigHexInt(x)
 	assert.Equal(t, (*big.Int)(&newBig).Uint64(), x)
 }
+
+func TestNewFluxMonitorJobSpec(t *testing.T) {
+	var triggers triggerfns.TriggerFns
+	require.NoError(t, triggers.Scan(`[
+		{"type": "relativeThreshold", "params": 0.005},
+		{"type": "staleness", "params": 3600}
+	]`))
+
+	job := NewFluxMonitorJobSpec(t, triggers)
+	require.Len(t, job.Initiators, 1)
+	initr := job.Initiators[0]
+	assert.Equal(t, models.InitiatorFluxMonitor, initr.Type)
+
+	value, err := initr.TriggerFns.Value()
+	require.NoError(t, err)
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, triggers.Equals(scanned), "got %s", scanned)
+}
+
+func TestMustHelloWorldAgreementWithOracles(t *testing.T) {
+	oracles := []common.Address{
+		common.HexToAddress("0x3cb8e3FD9d27e39a5e9e6852b0e96160061fd4ea"),
+		common.HexToAddress("0xa0788FC17B1dEe36f057c42B6F373A34B014687e"),
+		common.HexToAddress("0x00000000000000000000000000000000000000c1"),
+	}
+
+	agreement := MustHelloWorldAgreementWithOracles(t, oracles...)
+	actual := []string{}
+	for _, oracle := range gjson.Get(agreement, "oracles").Array() {
+		actual = append(actual, oracle.String())
+	}
+	assert.Equal(t, []string{
+		"0x3cb8e3FD9d27e39a5e9e6852b0e96160061fd4ea",
+		"0xa0788FC17B1dEe36f057c42B6F373A34B014687e",
+		oracles[2].Hex(),
+	}, actual)
+
+	single := MustHelloWorldAgreement(t, oracles[1])
+	assert.Equal(t, `["0xa0788FC17B1dEe36f057c42B6F373A34B014687e"]`, gjson.Get(single, "oracles").Raw)
+}
@@ -40,6 +40,21 @@ func LogFromFixture(t *testing.T, path string) eth.Log {
 	return el
 }
 
+// LogsFromFixture creates a slice of ethtypes.log from the array at
+// params.result in the given file path
+func LogsFromFixture(t *testing.T, path string) []eth.Log {
+	t.Helper()
+	value := gjson.Get(string(MustReadFile(t, path)), "params.result")
+	if value.IsObject() {
+		require.FailNow(t, "params.result holds a single log, not an array", "in %s; use LogFromFixture", path)
+	}
+	require.True(t, value.IsArray(), "params.result in %s must be an array of logs, got %s", path, value.Raw)
+
+	var logs []eth.Log
+	require.NoError(t, json.Unmarshal([]byte(value.Raw), &logs))
+	return logs
+}
+
 // TxReceiptFromFixture create ethtypes.log from file path
 func TxReceiptFromFixture(t *testing.T, path string) eth.TxReceipt {
 	jsonStr := JSONFromFixture(t, path).Get("result").String()
@@ -50,3 +65,16 @@ func TxReceiptFromFixture(t *testing.T, path string) eth.TxReceipt {
 
 	return receipt
 }
+
+// TxReceiptsFromFixture creates a slice of eth.TxReceipt from the array at
+// result in the given file path
+func TxReceiptsFromFixture(t *testing.T, path string) []eth.TxReceipt {
+	t.Helper()
+	value := JSONFromFixture(t, path).Get("result")
+	require.True(t, value.Exists(), "%s has no result", path)
+	require.True(t, value.IsArray(), "result in %s must be an array of receipts, got %s", path, value.Raw)
+
+	var receipts []eth.TxReceipt
+	require.NoError(t, json.Unmarshal([]byte(value.Raw), &receipts))
+	return receipts
+}
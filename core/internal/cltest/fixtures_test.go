@@ -0,0 +1,35 @@
+package cltest
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsFromFixture(t *testing.T) {
+	logs := LogsFromFixture(t, "testdata/three_logs.json")
+	require.Len(t, logs, 3)
+
+	for n, log := range logs {
+		assert.Equal(t, common.BigToHash(i(int64(n+1))), log.Topics[1], "log %d", n)
+	}
+	assert.Equal(t, uint64(10), logs[0].BlockNumber)
+	assert.Equal(t, uint(1), logs[1].Index)
+	assert.Equal(t, uint64(11), logs[2].BlockNumber)
+}
+
+func TestTxReceiptsFromFixture(t *testing.T) {
+	receipts := TxReceiptsFromFixture(t, "testdata/reorg_receipts.json")
+	require.Len(t, receipts, 3)
+
+	blockNumbers := []int64{}
+	for _, receipt := range receipts {
+		require.NotNil(t, receipt.BlockNumber)
+		blockNumbers = append(blockNumbers, receipt.BlockNumber.ToInt().Int64())
+	}
+	assert.Equal(t, []int64{11, 12, 11}, blockNumbers)
+	assert.Equal(t, receipts[0].Hash, receipts[2].Hash)
+	assert.NotEqual(t, *receipts[0].BlockHash, *receipts[2].BlockHash)
+}
@@ -459,6 +459,62 @@ func (t *TriggerClock) After(_ time.Duration) <-chan time.Time {
 	return t.triggers
 }
 
+// FakeClock implements the AfterNower interface with a time which only moves
+// when the test calls Advance.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewFakeClock returns a FakeClock whose time starts at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel which receives the clock's time once it has been
+// advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeClockWaiter{c.now.Add(d), ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, releasing every After channel whose
+// deadline has now passed.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
 // RendererMock a mock renderer
 type RendererMock struct {
 	Renders []interface{}
@@ -633,6 +689,77 @@ type MockCronEntry struct {
 	Function func()
 }
 
+// FakeCron implements services.Cron, running its functions only when the
+// test calls Trigger or TriggerEntry. Like a real cron, it runs nothing until
+// it is started, and accepts no functions once it has been stopped.
+type FakeCron struct {
+	mu      sync.Mutex
+	running bool
+	stopped bool
+	entries []MockCronEntry
+}
+
+// NewFakeCron returns a FakeCron which has not been started.
+func NewFakeCron() *FakeCron {
+	return &FakeCron{}
+}
+
+// Start lets Trigger and TriggerEntry run the cron's functions.
+func (fc *FakeCron) Start() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.running, fc.stopped = true, false
+}
+
+// Stop prevents any further functions from being added or run.
+func (fc *FakeCron) Stop() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.running, fc.stopped = false, true
+}
+
+// AddFunc records fn under schd, unless the cron has been stopped.
+func (fc *FakeCron) AddFunc(schd string, fn func()) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.stopped {
+		return errors.New("FakeCron is stopped")
+	}
+	fc.entries = append(fc.entries, MockCronEntry{Schedule: schd, Function: fn})
+	return nil
+}
+
+// Entries returns the functions added so far, in the order they were added.
+func (fc *FakeCron) Entries() []MockCronEntry {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return append([]MockCronEntry{}, fc.entries...)
+}
+
+// Trigger runs every function added so far, in order, if the cron is running.
+func (fc *FakeCron) Trigger() {
+	if !fc.isRunning() {
+		return
+	}
+	for _, entry := range fc.Entries() {
+		entry.Function()
+	}
+}
+
+// TriggerEntry runs the ith function added, if the cron is running.
+func (fc *FakeCron) TriggerEntry(i int) {
+	if !fc.isRunning() {
+		return
+	}
+	fc.Entries()[i].Function()
+}
+
+func (fc *FakeCron) isRunning() bool {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.running
+}
+
 // MockHeadTrackable allows you to mock HeadTrackable
 type MockHeadTrackable struct {
 	connectedCount    int32
@@ -0,0 +1,98 @@
+package cltest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ utils.AfterNower = (*FakeClock)(nil)
+	_ services.Cron    = (*FakeCron)(nil)
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Unix(1000000, 0)
+	clock := NewFakeClock(start)
+
+	soon := clock.After(time.Minute)
+	later := clock.After(time.Hour)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), clock.Now())
+	assertNotFired(t, soon)
+	assertNotFired(t, later)
+
+	clock.Advance(30 * time.Second)
+	select {
+	case fired := <-soon:
+		assert.Equal(t, start.Add(time.Minute), fired)
+	default:
+		t.Fatal("After(time.Minute) should fire once a minute has passed")
+	}
+	assertNotFired(t, later)
+
+	clock.Advance(time.Hour)
+	select {
+	case fired := <-later:
+		assert.Equal(t, start.Add(time.Hour+time.Minute), fired)
+	default:
+		t.Fatal("After(time.Hour) should fire once an hour has passed")
+	}
+}
+
+func TestFakeClock_AfterNonPositive(t *testing.T) {
+	start := time.Unix(1000000, 0)
+	clock := NewFakeClock(start)
+	assert.Equal(t, start, <-clock.After(0))
+}
+
+func assertNotFired(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Fatal("After channel fired early")
+	default:
+	}
+}
+
+func TestFakeCron_Trigger(t *testing.T) {
+	cron := NewFakeCron()
+	cron.Start()
+
+	var first, second int
+	require.NoError(t, cron.AddFunc("* * * * *", func() { first++ }))
+	require.NoError(t, cron.AddFunc("@hourly", func() { second++ }))
+	assert.Equal(t, "@hourly", cron.Entries()[1].Schedule)
+
+	cron.Trigger()
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 1, second)
+
+	cron.TriggerEntry(1)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 2, second)
+}
+
+func TestFakeCron_StartStop(t *testing.T) {
+	cron := NewFakeCron()
+
+	ran := 0
+	require.NoError(t, cron.AddFunc("* * * * *", func() { ran++ }))
+	cron.Trigger()
+	assert.Equal(t, 0, ran, "should not run before Start")
+
+	cron.Start()
+	cron.Trigger()
+	assert.Equal(t, 1, ran)
+
+	cron.Stop()
+	cron.Trigger()
+	assert.Equal(t, 1, ran, "should not run after Stop")
+	assert.Error(t, cron.AddFunc("* * * * *", func() {}))
+}
@@ -0,0 +1,41 @@
+package cltest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// AssertTriggers asserts that f reports a move from current to new, with an
+// empty TriggerContext.
+func AssertTriggers(t testing.TB, f triggerfns.TriggerFns, current, new float64) {
+	t.Helper()
+	assertTriggers(t, f, current, new, true)
+}
+
+// AssertNoTrigger asserts that f doesn't report a move from current to new,
+// with an empty TriggerContext.
+func AssertNoTrigger(t testing.TB, f triggerfns.TriggerFns, current, new float64) {
+	t.Helper()
+	assertTriggers(t, f, current, new, false)
+}
+
+func assertTriggers(t testing.TB, f triggerfns.TriggerFns, current, new float64, want bool) {
+	t.Helper()
+	c, err := triggerfns.DecimalFromFloat(current)
+	require.NoError(t, err, "invalid current answer")
+	n, err := triggerfns.DecimalFromFloat(new)
+	require.NoError(t, err, "invalid new answer")
+	fired, err := f.ShouldReport(context.Background(), c, n, triggerfns.TriggerContext{})
+	require.NoError(t, err, "while evaluating %s from %s to %s", f, c, n)
+	verb := "fire"
+	if !want {
+		verb = "not fire"
+	}
+	assert.Equal(t, want, fired, "expected %s to %s from %s to %s (relative deviation %s, absolute deviation %s)",
+		f, verb, c, n, triggerfns.RelativeDeviation(c, n), triggerfns.AbsoluteDeviation(c, n))
+}
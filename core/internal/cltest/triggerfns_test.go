@@ -0,0 +1,55 @@
+package cltest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failureRecorder is a testing.TB which records failures instead of
+// reporting them.
+type failureRecorder struct {
+	testing.TB
+	messages []string
+	failed   bool
+}
+
+func (r *failureRecorder) Helper() {}
+func (r *failureRecorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+func (r *failureRecorder) FailNow() { r.failed = true }
+
+func TestAssertTriggers(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01, "absoluteThreshold": 5}`))
+
+	AssertTriggers(t, tfns, 100, 102)
+	AssertTriggers(t, tfns, 1000, 1006)
+	AssertNoTrigger(t, tfns, 100, 100.5)
+	AssertNoTrigger(t, tfns, 1000, 1004)
+}
+
+func TestAssertTriggers_Failures(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+
+	r := &failureRecorder{TB: t}
+	AssertTriggers(r, tfns, 100, 100.5)
+	require.True(t, r.failed)
+	require.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], "expected relativeThreshold(0.01) to fire from 100 to 100.5")
+	assert.Contains(t, r.messages[0], "relative deviation 0.005")
+
+	r = &failureRecorder{TB: t}
+	AssertNoTrigger(r, tfns, 100, 102)
+	require.True(t, r.failed)
+	require.Len(t, r.messages, 1)
+	assert.Contains(t, r.messages[0], "expected relativeThreshold(0.01) to not fire from 100 to 102")
+	assert.Contains(t, r.messages[0], "absolute deviation 2")
+}
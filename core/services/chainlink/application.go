@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/smartcontractkit/chainlink/core/services"
 	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
 	"github.com/smartcontractkit/chainlink/core/services/synchronization"
 	"github.com/smartcontractkit/chainlink/core/store"
 	strpkg "github.com/smartcontractkit/chainlink/core/store"
@@ -77,6 +78,7 @@ func NewApplication(config *orm.Config, onConnectCallbacks ...func(Application))
 	shutdownSignal := gracefulpanic.NewSignal()
 	store := store.NewStore(config, shutdownSignal)
 	config.SetRuntimeStore(store.ORM)
+	triggerfns.SetDefaultOnEmpty(config.FluxMonitorDefaultTriggerFns())
 
 	statsPusher := synchronization.NewStatsPusher(
 		store.ORM, config.ExplorerURL(), config.ExplorerAccessKey(), config.ExplorerSecret(),
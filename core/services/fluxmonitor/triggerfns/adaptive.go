@@ -0,0 +1,88 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// adaptiveParams are the params of adaptiveThreshold, e.g.
+// {"base": 0.005, "multiplier": 2, "windowSize": 20}.
+type adaptiveParams struct {
+	Base       float64 `json:"base"`
+	Multiplier float64 `json:"multiplier"`
+	WindowSize int     `json:"windowSize"`
+}
+
+// adaptiveTriggerFn is a relativeThreshold whose threshold widens with
+// volatility: it fires when new differs from current by at least
+// base + multiplier * the standard deviation of the last windowSize returns,
+// the fractional moves between successive answers it was shown. In calm
+// periods the threshold falls back towards base. It records every answer,
+// fired on or not.
+type adaptiveTriggerFn struct {
+	params adaptiveParams
+
+	mu      sync.Mutex
+	last    float64   // the previous answer, or 0 if there was none
+	returns []float64 // ring buffer of the most recent returns
+	next    int       // index in returns of the oldest, once it is full
+}
+
+func adaptiveFactory(params interface{}) (TriggerFn, error) {
+	var p adaptiveParams
+	if err := decodeParams("adaptiveThreshold", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Base < 0 || p.Multiplier < 0 || math.IsInf(p.Base, 0) || math.IsInf(p.Multiplier, 0) {
+		return nil, errors.Errorf("adaptiveThreshold requires a finite, non-negative base and multiplier, got %+v", p)
+	}
+	if p.WindowSize < 2 {
+		return nil, errors.Errorf("adaptiveThreshold requires a windowSize of at least 2, got %d", p.WindowSize)
+	}
+	return &adaptiveTriggerFn{params: p}, nil
+}
+
+func (a *adaptiveTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	answer, _ := new.Float64()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// The answer is recorded even if the threshold is out of range, so that
+	// the returns which put it there leave the window in time.
+	threshold, thresholdErr := DecimalFromFloat(a.effectiveThreshold())
+	if a.last != 0 {
+		r := (answer - a.last) / a.last
+		if len(a.returns) == a.params.WindowSize {
+			a.returns[a.next] = r
+			a.next = (a.next + 1) % len(a.returns)
+		} else {
+			a.returns = append(a.returns, r)
+		}
+	}
+	a.last = answer
+	if thresholdErr != nil {
+		return false, errors.Wrap(thresholdErr, "adaptiveThreshold's threshold")
+	}
+	return relativeDeviationAtLeast(current, new, threshold), nil
+}
+
+// effectiveThreshold is the threshold the next answer is held to. It must be
+// called with a.mu held.
+func (a *adaptiveTriggerFn) effectiveThreshold() float64 {
+	if len(a.returns) < 2 {
+		return a.params.Base
+	}
+	_, stddev := meanAndStddev(a.returns)
+	return a.params.Base + a.params.Multiplier*stddev
+}
+
+// Clone returns an adaptiveThreshold with a's params and no history.
+func (a *adaptiveTriggerFn) Clone() TriggerFn { return &adaptiveTriggerFn{params: a.params} }
+
+func (a *adaptiveTriggerFn) stateful() bool          { return true }
+func (a *adaptiveTriggerFn) Factory() string         { return "adaptiveThreshold" }
+func (a *adaptiveTriggerFn) Parameters() interface{} { return a.params }
+func (a *adaptiveTriggerFn) ParamSchema() string     { return triggerFnSchemas["adaptiveThreshold"] }
@@ -0,0 +1,95 @@
+package triggerfns_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const adaptiveSpec = `{"adaptiveThreshold": {"base": 0.005, "multiplier": 2, "windowSize": 5}}`
+
+func TestAdaptiveThreshold_WidensWithVolatility(t *testing.T) {
+	tfn := mustScanOne(t, adaptiveSpec)
+	assert.Equal(t, 0.005, triggerfns.ExportedEffectiveThreshold(tfn), "no history")
+
+	feed(t, tfn, []float64{100, 100.05, 100, 100.05, 100, 100.05})
+	calm := triggerfns.ExportedEffectiveThreshold(tfn)
+	assert.InDelta(t, 0.006, calm, 0.0005, "calm")
+
+	feed(t, tfn, []float64{103, 98, 104, 97, 103})
+	volatile := triggerfns.ExportedEffectiveThreshold(tfn)
+	assert.Greater(t, volatile, 0.05, "volatile burst")
+
+	feed(t, tfn, []float64{100, 100.05, 100, 100.05, 100, 100.05})
+	assert.InDelta(t, calm, triggerfns.ExportedEffectiveThreshold(tfn), 0.0005, "calm again")
+}
+
+func TestAdaptiveThreshold_Triggering(t *testing.T) {
+	tfn := mustScanOne(t, adaptiveSpec)
+	move := func() bool {
+		fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102),
+			triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		return fired
+	}
+
+	feed(t, tfn, []float64{100, 100.05, 100, 100.05, 100})
+	assert.True(t, move(), "a 2% move fires when calm")
+
+	feed(t, tfn, []float64{103, 98, 104, 97, 103})
+	assert.False(t, move(), "a 2% move is noise in a volatile burst")
+}
+
+func TestAdaptiveThreshold_Clone(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(adaptiveSpec))
+	feed(t, tfns[0], []float64{103, 98, 104, 97, 103})
+
+	clone := tfns.Clone()
+	assert.Equal(t, 0.005, triggerfns.ExportedEffectiveThreshold(clone[0]))
+	assert.Greater(t, triggerfns.ExportedEffectiveThreshold(tfns[0]), 0.05)
+}
+
+func TestAdaptiveThreshold_ValueScanRoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(adaptiveSpec))
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "adaptiveThreshold", "params": {"base": 0.005, "multiplier": 2, "windowSize": 5}}]}`,
+		string(value.([]byte)))
+}
+
+func TestAdaptiveThreshold_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"adaptiveThreshold": 0.005}`,
+		`{"adaptiveThreshold": {"base": -0.005, "multiplier": 2, "windowSize": 5}}`,
+		`{"adaptiveThreshold": {"base": 0.005, "multiplier": -2, "windowSize": 5}}`,
+		`{"adaptiveThreshold": {"base": 0.005, "multiplier": 2, "windowSize": 1}}`,
+		`{"adaptiveThreshold": {"base": 0.005, "multiplier": 2}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
+
+func TestAdaptiveThreshold_OverflowingVolatility(t *testing.T) {
+	tfn := mustScanOne(t, adaptiveSpec)
+	// The move from 1e-300 to 1e300 is a return too large for a float.
+	feed(t, tfn, []float64{1e-300, 1e300, 1e300})
+
+	current, new := decimal.NewFromInt(100), decimal.NewFromFloat(1e300)
+	for i := 0; i < 4; i++ {
+		_, err := tfn.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, triggerfns.ErrNotFinite))
+	}
+	// Once the return has left the window, the threshold is usable again.
+	_, err := tfn.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,56 @@
+package triggerfns
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/shopspring/decimal"
+)
+
+// AtomicTriggerFns holds a job's trigger functions, so that they can be
+// replaced while the job runs. Evaluations already under way finish with the
+// functions they started with, and later ones use the replacement. It is safe
+// for concurrent use, and its zero value holds no functions.
+type AtomicTriggerFns struct {
+	mu    sync.Mutex // serializes Update
+	value atomic.Value
+}
+
+// NewAtomicTriggerFns returns an AtomicTriggerFns holding f.
+func NewAtomicTriggerFns(f TriggerFns) *AtomicTriggerFns {
+	a := &AtomicTriggerFns{}
+	a.value.Store(f)
+	return a
+}
+
+// Load returns the functions currently held.
+func (a *AtomicTriggerFns) Load() TriggerFns {
+	f, _ := a.value.Load().(TriggerFns)
+	return f
+}
+
+// Update replaces the functions held with f. Where a function in f has the
+// same position, name and params as one held, the one held is kept, so that
+// a stateful function doesn't lose the answers it has seen. Any other
+// function is taken from f as is.
+func (a *AtomicTriggerFns) Update(f TriggerFns) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	old := a.Load()
+	updated := make(TriggerFns, len(f))
+	for i, tfn := range f {
+		if i < len(old) && triggerFnEqual(old[i], tfn) {
+			updated[i] = old[i]
+		} else {
+			updated[i] = tfn
+		}
+	}
+	a.value.Store(updated)
+}
+
+// ShouldReport evaluates the functions currently held, as
+// TriggerFns.ShouldReport does.
+func (a *AtomicTriggerFns) ShouldReport(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	return a.Load().ShouldReport(ctx, current, new, tc)
+}
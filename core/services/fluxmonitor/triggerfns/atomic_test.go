@@ -0,0 +1,76 @@
+package triggerfns_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicTriggerFns_Update(t *testing.T) {
+	live := triggerfns.NewAtomicTriggerFns(mustScan(t, `{"relativeThreshold": 0.01}`))
+	ctx := context.Background()
+	current, new := decimal.NewFromInt(100), decimal.NewFromFloat(101.5)
+
+	fired, err := live.ShouldReport(ctx, current, new, triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+
+	live.Update(mustScan(t, `{"relativeThreshold": 0.02}`))
+	fired, err = live.ShouldReport(ctx, current, new, triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired, "still using the old threshold")
+	assert.Equal(t, "relativeThreshold(0.02)", live.Load().String())
+}
+
+func TestAtomicTriggerFns_UpdateKeepsState(t *testing.T) {
+	spec := `[{"type": "zscore", "params": {"windowSize": 4, "sigma": 2}}, {"type": "staleness", "params": 60}]`
+	live := triggerfns.NewAtomicTriggerFns(mustScan(t, spec))
+	zscore := func() triggerfns.TriggerFn { return live.Load().FilterByFactory("zscore")[0] }
+	feed(t, zscore(), []float64{100, 100.1, 99.9, 100})
+
+	// The zscore function keeps its place and params, so keeps its full
+	// window, and fires straight away on a spike.
+	live.Update(mustScan(t, `[{"type": "zscore", "params": {"windowSize": 4, "sigma": 2}}, {"type": "staleness", "params": 30}]`))
+	assert.Equal(t, `staleness(30), zscore({"windowSize":4,"sigma":2})`, live.Load().String())
+	assert.Equal(t, []bool{true}, feed(t, zscore(), []float64{110}))
+
+	// With new params, it starts over.
+	live.Update(mustScan(t, `[{"type": "zscore", "params": {"windowSize": 4, "sigma": 3}}, {"type": "staleness", "params": 30}]`))
+	assert.Equal(t, []bool{false}, feed(t, zscore(), []float64{130}))
+}
+
+func TestAtomicTriggerFns_ZeroValue(t *testing.T) {
+	var live triggerfns.AtomicTriggerFns
+	assert.Nil(t, live.Load())
+	fired, err := live.ShouldReport(context.Background(), decimal.NewFromInt(1), decimal.NewFromInt(2), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+}
+
+func TestAtomicTriggerFns_Concurrent(t *testing.T) {
+	live := triggerfns.NewAtomicTriggerFns(mustScan(t, `{"relativeThreshold": 0.01}`))
+	specs := []string{`{"relativeThreshold": 0.02}`, `{"ewmaThreshold": {"alpha": 0.5, "threshold": 0.01}}`}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if g == 0 {
+					live.Update(mustScan(t, specs[i%len(specs)]))
+					continue
+				}
+				_, err := live.ShouldReport(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(int64(100+i)),
+					triggerfns.TriggerContext{})
+				assert.NoError(t, err)
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,50 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// AuditSink receives every evaluation made by the trigger functions returned
+// by TriggerFns.WithAudit, with its inputs and outcome. Record is called after
+// each evaluation, from whichever goroutine made it, so implementations must
+// be safe for concurrent use.
+type AuditSink interface {
+	Record(factory string, current, new decimal.Decimal, fired bool, err error)
+}
+
+// NopAuditSink discards every evaluation.
+var NopAuditSink AuditSink = nopAuditSink{}
+
+type nopAuditSink struct{}
+
+func (nopAuditSink) Record(string, decimal.Decimal, decimal.Decimal, bool, error) {}
+
+// WithAudit returns a copy of f whose functions report each evaluation to
+// sink, including those which err. Only the top level functions report, so a
+// composite like "and" is recorded once, under its own name. Any metrics f's
+// functions report to are kept. A nil sink is treated as NopAuditSink.
+func (f TriggerFns) WithAudit(sink AuditSink) TriggerFns {
+	if sink == nil {
+		sink = NopAuditSink
+	}
+	audited := make(TriggerFns, len(f))
+	for i, tfn := range f {
+		m := asMetered(tfn)
+		m.audit = sink
+		audited[i] = m
+	}
+	return audited
+}
+
+// DryRun returns a copy of f whose functions report each evaluation to sink,
+// with the outcome it would have had, but never fire, so that ShouldReport
+// always returns false. Errors are still returned. It lets a new spec be
+// tried against live answers without reporting any of them. A nil sink is
+// treated as NopAuditSink.
+func (f TriggerFns) DryRun(sink AuditSink) TriggerFns {
+	dryRun := f.WithAudit(sink)
+	for i, tfn := range dryRun {
+		m := tfn.(meteredTriggerFn)
+		m.dryRun = true
+		dryRun[i] = m
+	}
+	return dryRun
+}
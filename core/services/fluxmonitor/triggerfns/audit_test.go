@@ -0,0 +1,109 @@
+package triggerfns_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type auditRecord struct {
+	factory      string
+	current, new string
+	fired        bool
+	err          error
+}
+
+// recordingSink keeps every evaluation it is given.
+type recordingSink struct {
+	mu      sync.Mutex
+	records []auditRecord
+}
+
+func (s *recordingSink) Record(factory string, current, new decimal.Decimal, fired bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, auditRecord{factory, current.String(), new.String(), fired, err})
+}
+
+func TestWithAudit(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+	sink := &recordingSink{}
+	audited := tfns.WithAudit(sink)
+	ctx := context.Background()
+
+	fired, err := audited.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+	fired, err = audited.ShouldReport(ctx, decimal.NewFromInt(100), decimal.RequireFromString("100.5"), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+
+	assert.Equal(t, []auditRecord{
+		{"relativeThreshold", "100", "102", true, nil},
+		{"relativeThreshold", "100", "100.5", false, nil},
+	}, sink.records)
+
+	// The original functions aren't audited.
+	_, err = tfns.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.Len(t, sink.records, 2)
+}
+
+func TestWithAudit_KeepsMetricsAndClones(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"absoluteThreshold": 1}`))
+	sink := &recordingSink{}
+	reg := prometheus.NewRegistry()
+	metered, err := tfns.WithAudit(sink).WithMetrics(reg)
+	require.NoError(t, err)
+	assert.Equal(t, "absoluteThreshold(1)", metered.String())
+
+	clone := metered.Clone()
+	fired, err := clone.ShouldReport(context.Background(), decimal.NewFromInt(10), decimal.NewFromInt(12), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+	require.Len(t, sink.records, 1)
+	assert.Equal(t, "absoluteThreshold", sink.records[0].factory)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "absoluteThreshold", "fired"))
+}
+
+func TestWithAudit_NilSink(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"absoluteThreshold": 1}`))
+	fired, err := tfns.WithAudit(nil).ShouldReport(context.Background(), decimal.NewFromInt(10), decimal.NewFromInt(12),
+		triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestDryRun(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01, "absoluteThreshold": 5}`))
+	sink := &recordingSink{}
+	dryRun := tfns.DryRun(sink)
+	ctx := context.Background()
+
+	fired, err := dryRun.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+	assert.Contains(t, sink.records, auditRecord{"relativeThreshold", "100", "102", true, nil}, "would fire")
+
+	result, err := triggerfns.TriggeringWithReason(ctx, dryRun[0], decimal.NewFromInt(100), decimal.NewFromInt(110),
+		triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, result.Fired)
+	assert.Contains(t, result.Reason, "dry run, would have fired")
+
+	// The original functions still fire.
+	fired, err = tfns.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
@@ -0,0 +1,50 @@
+package triggerfns
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// bandParams are the params of band. Both are percentages of current, so
+// {"upPct": 1, "downPct": 0.5} fires on a rise of 1% or a fall of 0.5%.
+type bandParams struct {
+	UpPct   float64 `json:"upPct"`
+	DownPct float64 `json:"downPct"`
+}
+
+// bandTriggerFn fires when new rises above current by at least upPct percent,
+// or falls below it by at least downPct percent. Like relativeThreshold, it
+// counts a move away from a zero current as a deviation of
+// ZeroCurrentDeviation, so that it fires from zero unless the percentage for
+// the move's direction is greater than that.
+type bandTriggerFn struct {
+	params   bandParams
+	up, down decimal.Decimal
+}
+
+func bandFactory(params interface{}) (TriggerFn, error) {
+	var p bandParams
+	if err := decodeParams("band", params, &p); err != nil {
+		return nil, err
+	}
+	if p.UpPct < 0 || p.DownPct < 0 {
+		return nil, errors.Errorf("band requires non-negative percentages, got %+v", p)
+	}
+	hundred := decimal.NewFromInt(100)
+	return bandTriggerFn{
+		params: p,
+		up:     decimal.NewFromFloat(p.UpPct).Div(hundred),
+		down:   decimal.NewFromFloat(p.DownPct).Div(hundred),
+	}, nil
+}
+
+func (b bandTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	if new.GreaterThan(current) {
+		return relativeDeviationAtLeast(current, new, b.up), nil
+	}
+	return relativeDeviationAtLeast(current, new, b.down), nil
+}
+
+func (b bandTriggerFn) Factory() string         { return "band" }
+func (b bandTriggerFn) Parameters() interface{} { return b.params }
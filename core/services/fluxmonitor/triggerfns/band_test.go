@@ -0,0 +1,84 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBand_Asymmetric(t *testing.T) {
+	tfn := mustScanOne(t, `[{"type": "band", "params": {"upPct": 5, "downPct": 1}}]`)
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"unchanged", "100", "100", false},
+		{"3% rise", "100", "103", false},
+		{"3% fall", "100", "97", true},
+		{"5% rise", "100", "105", true},
+		{"1% fall", "100", "99", true},
+		{"0.5% fall", "100", "99.5", false},
+		{"1% rise from a negative current", "-100", "-99", false},
+		{"1% fall from a negative current", "-100", "-101", true},
+		{"zero current", "0", "0.001", true},
+		{"zero current and new", "0", "0", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current), decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestBand_ZeroCurrent(t *testing.T) {
+	// As with relativeThreshold, a move away from zero is a 100% deviation.
+	tfn := mustScanOne(t, `{"band": {"upPct": 150, "downPct": 50}}`)
+	fired, err := tfn.Triggering(context.Background(), decimal.Zero, decimal.NewFromInt(5), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired, "a rise from zero is short of 150%")
+	fired, err = tfn.Triggering(context.Background(), decimal.Zero, decimal.NewFromInt(-5), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired, "a fall from zero reaches 50%")
+}
+
+func TestBand_Parameters(t *testing.T) {
+	original := triggerfns.TriggerFns{mustScanOne(t, `{"band": {"upPct": 5, "downPct": 1}}`)}
+
+	params, err := json.Marshal(original[0].Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"upPct": 5, "downPct": 1}`, string(params))
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, original.Equals(scanned))
+}
+
+func TestBand_BadParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"bare number", `{"band": 5}`},
+		{"missing downPct", `{"band": {"upPct": 5}}`},
+		{"negative upPct", `{"band": {"upPct": -5, "downPct": 1}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.params))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ShouldReportBatch returns, for each {current, new} pair, what ShouldReport
+// would for it, sharing tc between them. Pairs are evaluated in order, so a
+// stateful function sees them as successive answers. If any evaluation
+// fails, ShouldReportBatch returns that error, naming the pair.
+//
+// When f holds only threshold functions such as relativeThreshold, they are
+// evaluated directly, without the per-pair overhead of ShouldReport.
+func (f TriggerFns) ShouldReportBatch(ctx context.Context, pairs [][2]decimal.Decimal, tc TriggerContext) ([]bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	results := make([]bool, len(pairs))
+	if thresholds, ok := f.thresholdFns(); ok {
+		for i, pair := range pairs {
+			for _, t := range thresholds {
+				if t.triggering(t.current(pair[0], tc), pair[1]) {
+					results[i] = true
+					break
+				}
+			}
+		}
+		return results, nil
+	}
+	for i, pair := range pairs {
+		fired, err := f.ShouldReport(ctx, pair[0], pair[1], tc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while evaluating pair %d", i)
+		}
+		results[i] = fired
+	}
+	return results, nil
+}
+
+// thresholdFns returns f's functions as thresholdTriggerFns, if they all are.
+// Metered functions are excluded, so that their evaluations are recorded.
+func (f TriggerFns) thresholdFns() ([]thresholdTriggerFn, bool) {
+	thresholds := make([]thresholdTriggerFn, len(f))
+	for i, tfn := range f {
+		t, ok := tfn.(thresholdTriggerFn)
+		if !ok {
+			return nil, false
+		}
+		thresholds[i] = t
+	}
+	return thresholds, true
+}
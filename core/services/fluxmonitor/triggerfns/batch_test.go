@@ -0,0 +1,69 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// walkPairs pairs successive prices of a price walk as {current, new}.
+func walkPairs(n int) [][2]decimal.Decimal {
+	prices := priceWalk(benchmarkSeed, n+1, 1234.5678)
+	pairs := make([][2]decimal.Decimal, n)
+	for i := range pairs {
+		pairs[i] = [2]decimal.Decimal{prices[i], prices[i+1]}
+	}
+	return pairs
+}
+
+func TestTriggerFns_ShouldReportBatch_MatchesShouldReport(t *testing.T) {
+	pairs := append(walkPairs(200),
+		[2]decimal.Decimal{decimal.Zero, decimal.NewFromInt(1)},
+		[2]decimal.Decimal{decimal.NewFromInt(100), decimal.NewFromInt(100)})
+	ctx := context.Background()
+
+	for _, spec := range []string{
+		`{"relativeThreshold": 0.002}`,
+		`{"relativeThreshold": 0.003, "absoluteThreshold": 5, "relativeBps": 40}`,
+		`{"and": {"relativeThreshold": 0.002, "absoluteThreshold": 3}, "decreaseThreshold": 4}`,
+		`{}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(spec))
+		metered, err := tfns.WithMetrics(prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		for _, fns := range []triggerfns.TriggerFns{tfns, metered} {
+			batch, err := fns.ShouldReportBatch(ctx, pairs, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			require.Len(t, batch, len(pairs))
+			someFired := false
+			for i, pair := range pairs {
+				fired, err := fns.ShouldReport(ctx, pair[0], pair[1], triggerfns.TriggerContext{})
+				require.NoError(t, err)
+				assert.Equal(t, fired, batch[i], "%s, pair %d", spec, i)
+				someFired = someFired || fired
+			}
+			assert.Equal(t, spec != `{}`, someFired, spec)
+		}
+	}
+}
+
+func TestTriggerFns_ShouldReportBatch_Errors(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"percentOfReference": 2}`))
+	_, err := tfns.ShouldReportBatch(context.Background(), walkPairs(3), triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "while evaluating pair 0: percentOfReference requires a reference value")
+
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tfns.ShouldReportBatch(ctx, walkPairs(3), triggerfns.TriggerContext{})
+	assert.Equal(t, context.Canceled, err)
+}
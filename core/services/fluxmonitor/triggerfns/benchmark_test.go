@@ -0,0 +1,114 @@
+package triggerfns_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// benchmarkSeed fixes the price walk the benchmarks evaluate, so that results
+// are comparable between runs and commits.
+const benchmarkSeed = 1
+
+// priceWalk returns n prices starting from start, each moving from the last
+// by a normally distributed fraction with standard deviation 0.2%, as a feed
+// polled every few seconds might. The walk is determined by seed. Prices are
+// rounded to 8 places, like most feeds' answers.
+func priceWalk(seed int64, n int, start float64) []decimal.Decimal {
+	r := rand.New(rand.NewSource(seed))
+	prices := make([]decimal.Decimal, n)
+	price := start
+	for i := range prices {
+		price *= 1 + r.NormFloat64()*0.002
+		prices[i] = decimal.NewFromFloat(price).Round(8)
+	}
+	return prices
+}
+
+// benchmarkTriggerFn feeds the price walk to the function spec describes the
+// way the flux monitor does, moving current to the new answer whenever the
+// function fires.
+func benchmarkTriggerFn(b *testing.B, spec string) {
+	var tfns triggerfns.TriggerFns
+	if err := tfns.Scan(spec); err != nil {
+		b.Fatal(err)
+	}
+	tfn := tfns[0]
+	prices := priceWalk(benchmarkSeed, 1024, 1234.5678)
+	ctx := context.Background()
+	current := prices[0]
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		new := prices[i%len(prices)]
+		fired, err := tfn.Triggering(ctx, current, new, triggerfns.TriggerContext{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if fired {
+			current = new
+		}
+	}
+}
+
+// BenchmarkTriggering measures each kind of trigger function over the same
+// price walk. Run it with -bench Triggering before and after adding or
+// changing a trigger function, and compare the results with benchstat.
+func BenchmarkTriggering(b *testing.B) {
+	for _, bm := range []struct{ name, spec string }{
+		{"relativeThreshold", `{"relativeThreshold": 0.005}`},
+		{"relativeBps", `{"relativeBps": 50}`},
+		{"absoluteThreshold", `{"absoluteThreshold": 5}`},
+		{"and", `{"and": {"relativeThreshold": 0.005, "absoluteThreshold": 5}}`},
+		{"or", `{"or": {"relativeThreshold": 0.005, "absoluteThreshold": 5}}`},
+		{"nested", `{"or": {"and": {"relativeThreshold": 0.005, "absoluteThreshold": 5}, "increaseThreshold": 10}}`},
+	} {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) { benchmarkTriggerFn(b, bm.spec) })
+	}
+}
+
+func TestPriceWalk_Deterministic(t *testing.T) {
+	walk := priceWalk(benchmarkSeed, 100, 1234.5678)
+	assert.Equal(t, walk, priceWalk(benchmarkSeed, 100, 1234.5678))
+	assert.NotEqual(t, walk, priceWalk(benchmarkSeed+1, 100, 1234.5678))
+	for _, price := range walk {
+		assert.True(t, price.IsPositive())
+	}
+}
+
+// BenchmarkShouldReportBatch compares evaluating a price walk's pairs in one
+// ShouldReportBatch call with calling ShouldReport for each.
+func BenchmarkShouldReportBatch(b *testing.B) {
+	var tfns triggerfns.TriggerFns
+	if err := tfns.Scan(`{"relativeThreshold": 0.005, "absoluteThreshold": 5}`); err != nil {
+		b.Fatal(err)
+	}
+	pairs := walkPairs(256)
+	ctx := context.Background()
+
+	b.Run("batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := tfns.ShouldReportBatch(ctx, pairs, triggerfns.TriggerContext{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("perPair", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, pair := range pairs {
+				if _, err := tfns.ShouldReport(ctx, pair[0], pair[1], triggerfns.TriggerContext{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
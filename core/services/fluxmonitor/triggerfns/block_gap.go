@@ -0,0 +1,82 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["minBlockGap"] = FactoryFunc(minBlockGapFactory)
+}
+
+// minBlockGapParams are the params of minBlockGap, e.g.
+// {"blocks": 20, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type minBlockGapParams struct {
+	Blocks    uint64        `json:"blocks"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// minBlockGapTriggerFn fires when its inner function does, unless fewer than
+// blocks blocks have passed since the last report. Unlike cooldown, the gap is
+// measured in blocks rather than wall-clock time. The inner function is
+// evaluated either way, so that a stateful one sees every answer.
+type minBlockGapTriggerFn struct {
+	blocks uint64
+	inner  TriggerFn
+}
+
+func minBlockGapFactory(params interface{}) (TriggerFn, error) {
+	var p minBlockGapParams
+	if err := decodeParams("minBlockGap", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Blocks == 0 {
+		return nil, errors.New("minBlockGap requires a positive number of blocks")
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing minBlockGap's inner trigger function")
+	}
+	return minBlockGapTriggerFn{blocks: p.Blocks, inner: inner}, nil
+}
+
+// Triggering requires tc.BlockNumber. A zero tc.LastReportedBlock means there
+// is no earlier report to keep a gap from.
+func (m minBlockGapTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := m.inner.Triggering(ctx, current, new, tc)
+	if err != nil || !fired {
+		return false, err
+	}
+	if tc.BlockNumber == 0 {
+		return false, errors.New("minBlockGap requires the current block number")
+	}
+	if tc.LastReportedBlock == 0 {
+		return true, nil
+	}
+	return tc.BlockNumber >= tc.LastReportedBlock && tc.BlockNumber-tc.LastReportedBlock >= m.blocks, nil
+}
+
+// Clone returns a minBlockGap around a clone of m's inner function.
+func (m minBlockGapTriggerFn) Clone() TriggerFn {
+	return minBlockGapTriggerFn{blocks: m.blocks, inner: cloneTriggerFn(m.inner)}
+}
+
+func (m minBlockGapTriggerFn) Parameters() interface{} {
+	return minBlockGapParams{
+		Blocks:    m.blocks,
+		TriggerFn: triggerFnJSON{Type: m.inner.Factory(), Params: m.inner.Parameters()},
+	}
+}
+
+func (m minBlockGapTriggerFn) SetClock(clock utils.AfterNower) { TriggerFns{m.inner}.SetClock(clock) }
+func (m minBlockGapTriggerFn) stateful() bool                  { return isStateful(m.inner) }
+func (m minBlockGapTriggerFn) Factory() string                 { return "minBlockGap" }
+func (m minBlockGapTriggerFn) ParamSchema() string             { return triggerFnSchemas["minBlockGap"] }
+func (m minBlockGapTriggerFn) String() string {
+	return fmt.Sprintf("minBlockGap(%d, %s)", m.blocks, triggerFnString(m.inner))
+}
@@ -0,0 +1,72 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const minBlockGapSpec = `{"minBlockGap": {"blocks": 20, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`
+
+func TestMinBlockGap(t *testing.T) {
+	tfn := mustScanOne(t, minBlockGapSpec)
+
+	tests := []struct {
+		name                   string
+		new                    string
+		block, lastReportBlock uint64
+		wantTriggered          bool
+	}{
+		{"deviation before gap", "110", 1019, 1000, false},
+		{"deviation at gap", "110", 1020, 1000, true},
+		{"deviation after gap", "110", 1021, 1000, true},
+		{"no deviation after gap", "100.5", 1100, 1000, false},
+		{"no earlier report", "110", 1005, 0, true},
+		{"last report ahead of block", "110", 990, 1000, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.RequireFromString(test.new),
+				triggerfns.TriggerContext{BlockNumber: test.block, LastReportedBlock: test.lastReportBlock})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestMinBlockGap_RequiresBlockNumber(t *testing.T) {
+	tfn := mustScanOne(t, minBlockGapSpec)
+	_, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(110),
+		triggerfns.TriggerContext{LastReportedBlock: 1000})
+	assert.EqualError(t, err, "minBlockGap requires the current block number")
+}
+
+func TestMinBlockGap_ValueScanRoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(minBlockGapSpec))
+	require.NoError(t, tfns.Validate())
+	assert.Equal(t, "minBlockGap(20, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "minBlockGap", "params": {"blocks": 20,
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}}}]}`, string(value.([]byte)))
+}
+
+func TestMinBlockGap_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"minBlockGap": 20}`,
+		`{"minBlockGap": {"blocks": 0, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+		`{"minBlockGap": {"blocks": -1, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+		`{"minBlockGap": {"blocks": 2.5, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+		`{"minBlockGap": {"blocks": 20}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
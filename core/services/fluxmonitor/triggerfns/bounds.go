@@ -0,0 +1,58 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// boundTriggerFn fires whenever new lies beyond a fixed bound, however
+// current compares to it. Combined under or, a floor and a ceiling report any
+// answer outside an allowed range.
+type boundTriggerFn struct {
+	factory      string
+	bound        float64
+	boundDecimal decimal.Decimal
+	// beyond is -1 for a floor, which fires below its bound, or 1 for a
+	// ceiling, which fires above it.
+	beyond int
+}
+
+// floorFactory returns a TriggerFn which fires when new is below the bound,
+// given as a bare number or as {"bound": number}.
+func floorFactory(params interface{}) (TriggerFn, error) {
+	return boundFactory("floor", -1, params)
+}
+
+// ceilingFactory returns a TriggerFn which fires when new is above the bound,
+// given as a bare number or as {"bound": number}.
+func ceilingFactory(params interface{}) (TriggerFn, error) {
+	return boundFactory("ceiling", 1, params)
+}
+
+func boundFactory(factory string, beyond int, params interface{}) (TriggerFn, error) {
+	var p struct {
+		Bound float64 `json:"bound"`
+	}
+	if err := decodeParams(factory, params, &p); err != nil {
+		return nil, err
+	}
+	if math.IsNaN(p.Bound) || math.IsInf(p.Bound, 0) {
+		return nil, errors.Errorf("%s requires a finite bound, got %v", factory, p.Bound)
+	}
+	return boundTriggerFn{
+		factory:      factory,
+		bound:        p.Bound,
+		boundDecimal: decimal.NewFromFloat(p.Bound),
+		beyond:       beyond,
+	}, nil
+}
+
+func (b boundTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	return new.Cmp(b.boundDecimal) == b.beyond, nil
+}
+
+func (b boundTriggerFn) Factory() string         { return b.factory }
+func (b boundTriggerFn) Parameters() interface{} { return b.bound }
@@ -0,0 +1,61 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloorAndCeiling(t *testing.T) {
+	floor := mustScanOne(t, `{"floor": 0.98}`)
+	ceiling := mustScanOne(t, `[{"type": "ceiling", "params": {"bound": 1.02}}]`)
+	assert.Equal(t, 0.98, floor.Parameters())
+	assert.Equal(t, 1.02, ceiling.Parameters())
+
+	tests := []struct {
+		name          string
+		tfn           triggerfns.TriggerFn
+		new           string
+		wantTriggered bool
+	}{
+		{"below floor", floor, "0.97", true},
+		{"at floor", floor, "0.98", false},
+		{"above floor", floor, "0.99", false},
+		{"below ceiling", ceiling, "1.01", false},
+		{"at ceiling", ceiling, "1.02", false},
+		{"above ceiling", ceiling, "1.03", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// current is ignored, even when it lies beyond the bound too
+			for _, current := range []string{"0.5", "1", "1.5"} {
+				fired, err := test.tfn.Triggering(context.Background(), decimal.RequireFromString(current),
+					decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+				require.NoError(t, err)
+				assert.Equal(t, test.wantTriggered, fired, "current %s", current)
+			}
+		})
+	}
+}
+
+func TestFloorAndCeiling_OutOfRange(t *testing.T) {
+	outOfRange := mustScanOne(t, `{"or": {"floor": 0.98, "ceiling": 1.02}}`)
+
+	for new, expected := range map[string]bool{"0.9": true, "1": false, "1.1": true} {
+		fired, err := outOfRange.Triggering(context.Background(), decimal.NewFromInt(1),
+			decimal.RequireFromString(new), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, fired, new)
+	}
+}
+
+func TestFloorAndCeiling_BadParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"floor": "0.98"}`))
+	assert.Error(t, tfns.Scan(`{"ceiling": {"max": 1.02}}`))
+}
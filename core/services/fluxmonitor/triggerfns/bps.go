@@ -0,0 +1,37 @@
+package triggerfns
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// relativeBpsFactory returns a TriggerFn which behaves like relativeThreshold,
+// with its threshold given as a whole number of basis points, so that 25
+// means a deviation of 0.25%. The basis points may be given as a bare number
+// or as {"bps": number}.
+func relativeBpsFactory(params interface{}) (TriggerFn, error) {
+	if bps, ok := params.(int); ok { // as returned by Parameters
+		params = float64(bps)
+	}
+	var p struct {
+		Bps float64 `json:"bps"`
+	}
+	if err := decodeParams("relativeBps", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Bps < 0 || p.Bps != math.Trunc(p.Bps) || p.Bps > math.MaxInt32 {
+		return nil, errors.Errorf("relativeBps requires a non-negative whole number of basis points, got %v", p.Bps)
+	}
+	bps := int(p.Bps)
+	threshold := decimal.New(int64(bps), -4)
+	bounds := newRelativeBounds(threshold)
+	return thresholdTriggerFn{
+		factory:    "relativeBps",
+		parameter:  bps,
+		threshold:  threshold,
+		triggering: bounds.atLeast,
+		measure:    relativeMeasure,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package triggerfns_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeBps_MatchesRelativeThreshold(t *testing.T) {
+	bps := mustScanOne(t, `{"relativeBps": 25}`)
+	relative := mustScanOne(t, `{"relativeThreshold": 0.0025}`)
+	assert.Equal(t, 25, bps.Parameters())
+
+	for _, new := range []string{"100", "100.24", "100.2499", "100.25", "100.26", "99.75", "99.7501", "99"} {
+		current, new := decimal.NewFromInt(100), decimal.RequireFromString(new)
+		bpsFired, err := bps.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		relativeFired, err := relative.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, relativeFired, bpsFired, "new %s", new)
+	}
+
+	fired, err := bps.Triggering(context.Background(), decimal.NewFromInt(100),
+		decimal.RequireFromString("100.25"), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+	fired, err = bps.Triggering(context.Background(), decimal.NewFromInt(100),
+		decimal.RequireFromString("100.2499"), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+}
+
+func TestRelativeBps_ValueScanRoundTrip(t *testing.T) {
+	tfns := triggerfns.TriggerFns{mustScanOne(t, `[{"type": "relativeBps", "params": {"bps": 25}}]`)}
+	require.NoError(t, tfns.Validate())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":2,"functions":[{"type":"relativeBps","params":25}]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, 25, scanned[0].Parameters())
+	assert.Equal(t, "relativeBps(25)", scanned[0].(fmt.Stringer).String())
+}
+
+func TestRelativeBps_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"relativeBps": -1}`,
+		`{"relativeBps": 2.5}`,
+		`{"relativeBps": "25"}`,
+		`{"relativeBps": {"pct": 25}}`,
+		`{"relativeBps": 1e300}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
@@ -0,0 +1,29 @@
+package triggerfns
+
+// Cloner is implemented by trigger functions which hold per-instance state,
+// or state behind a pointer. Clone returns an independent function with the
+// same parameters, its state reset as if newly constructed.
+type Cloner interface {
+	Clone() TriggerFn
+}
+
+// Clone returns a copy of f whose functions share no mutable state with f's,
+// so that each can be evaluated from its own goroutine. Functions which
+// implement Cloner are cloned, and any others are copied as values.
+func (f TriggerFns) Clone() TriggerFns {
+	if f == nil {
+		return nil
+	}
+	clone := make(TriggerFns, len(f))
+	for i, tfn := range f {
+		clone[i] = cloneTriggerFn(tfn)
+	}
+	return clone
+}
+
+func cloneTriggerFn(tfn TriggerFn) TriggerFn {
+	if c, ok := tfn.(Cloner); ok {
+		return c.Clone()
+	}
+	return tfn
+}
@@ -0,0 +1,61 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerFns_Clone_ResetsStatefulFunctions(t *testing.T) {
+	original := triggerfns.TriggerFns{mustScanOne(t, `{"hysteresis": {"upper": 0.02, "lower": 0.01}}`)}
+
+	// Fire the original, leaving it disarmed with 102 as its reference.
+	assert.Equal(t, []bool{true}, pollSequence(t, original[0], 100, []float64{102}))
+
+	clone := original.Clone()
+	require.True(t, original.Equals(clone))
+
+	// The clone starts afresh, and firing it disarms only the clone.
+	assert.Equal(t, []bool{true, false}, pollSequence(t, clone[0], 100, []float64{102, 104.1}))
+	// The original still measures from 102: it re-arms, then fires.
+	assert.Equal(t, []bool{false, true}, pollSequence(t, original[0], 100, []float64{102, 104.1}))
+}
+
+func TestTriggerFns_Clone_Composites(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`{"or": {"hysteresis": {"upper": 0.02, "lower": 0.01}, "staleness": 3600}}`))
+	lastReportedAt := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(lastReportedAt)
+	original.SetClock(clock)
+
+	tc := triggerfns.TriggerContext{LastReportedAt: lastReportedAt}
+	fired, err := original[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102), tc)
+	require.NoError(t, err)
+	require.True(t, fired)
+
+	// The clone's hysteresis is armed, and its staleness uses the same clock.
+	clone := original.Clone()
+	fired, err = clone[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102), tc)
+	require.NoError(t, err)
+	assert.True(t, fired)
+	clock.AssertExpectations(t)
+}
+
+func TestTriggerFns_Clone_Stateless(t *testing.T) {
+	original := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.01), mustAbsoluteThreshold(t, 2)}
+	clone := original.Clone()
+	assert.True(t, original.Equals(clone))
+
+	clone[0] = mustRelativeThreshold(t, 0.05)
+	assert.Equal(t, 0.01, original[0].Parameters())
+
+	assert.Nil(t, triggerfns.TriggerFns(nil).Clone())
+}
@@ -0,0 +1,233 @@
+package triggerfns
+
+import (
+	"context"
+	"sort"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	// Composites build their inner functions through makeTriggerFn, so they
+	// can't appear in the triggerFnFactories literal without an
+	// initialization cycle.
+	triggerFnFactories["and"] = FactoryFunc(andTriggerFnFactory)
+	triggerFnFactories["or"] = FactoryFunc(orTriggerFnFactory)
+	triggerFnFactories["not"] = FactoryFunc(notTriggerFnFactory)
+}
+
+// stateful is implemented by trigger functions whose result depends on the
+// answers they have previously been shown, and so must see every evaluation.
+type stateful interface {
+	stateful() bool
+}
+
+func isStateful(tfn TriggerFn) bool {
+	s, ok := tfn.(stateful)
+	return ok && s.stateful()
+}
+
+// compositeTriggerFn combines its inner functions, firing when all of them
+// fire or, if any is set, when at least one does. Evaluation normally stops at
+// the first inner function which settles the result or returns an error, but
+// if any inner function is stateful they are all evaluated, so none of them
+// misses an answer. Either way, evaluation stops with ctx.Err() once ctx is
+// done.
+type compositeTriggerFn struct {
+	factory string
+	fns     TriggerFns
+	any     bool
+}
+
+// andTriggerFnFactory expects an object mapping inner factory names to their
+// params, e.g. {"relativeThreshold": 0.005, "absoluteThreshold": 0.01}.
+func andTriggerFnFactory(params interface{}) (TriggerFn, error) {
+	fns, err := makeInnerTriggerFns("and", params)
+	if err != nil {
+		return nil, err
+	}
+	return compositeTriggerFn{factory: "and", fns: fns}, nil
+}
+
+// orTriggerFnFactory takes the same params as andTriggerFnFactory.
+func orTriggerFnFactory(params interface{}) (TriggerFn, error) {
+	fns, err := makeInnerTriggerFns("or", params)
+	if err != nil {
+		return nil, err
+	}
+	return compositeTriggerFn{factory: "or", fns: fns, any: true}, nil
+}
+
+func (c compositeTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	if c.stateful() {
+		return c.evaluateAll(ctx, current, new, tc)
+	}
+	for _, tfn := range c.fns {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		fired, err := tfn.Triggering(ctx, current, new, tc)
+		if err != nil {
+			return false, err
+		}
+		if fired == c.any {
+			return c.any, nil
+		}
+	}
+	return !c.any, nil
+}
+
+// evaluateAll runs every inner function, returning the first error if any of
+// them fails.
+func (c compositeTriggerFn) evaluateAll(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	var firstErr error
+	settled := false
+	for _, tfn := range c.fns {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		fired, err := tfn.Triggering(ctx, current, new, tc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if fired == c.any {
+			settled = true
+		}
+	}
+	if firstErr != nil {
+		return false, firstErr
+	}
+	return settled == c.any, nil
+}
+
+func (c compositeTriggerFn) stateful() bool {
+	for _, tfn := range c.fns {
+		if isStateful(tfn) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clone returns a composite of clones of c's inner functions.
+func (c compositeTriggerFn) Clone() TriggerFn {
+	return compositeTriggerFn{factory: c.factory, fns: c.fns.Clone(), any: c.any}
+}
+
+func (c compositeTriggerFn) SetClock(clock utils.AfterNower) { c.fns.SetClock(clock) }
+func (c compositeTriggerFn) Factory() string                 { return c.factory }
+func (c compositeTriggerFn) Parameters() interface{}         { return innerParameters(c.fns) }
+func (c compositeTriggerFn) String() string                  { return c.factory + "(" + c.fns.String() + ")" }
+
+// ShouldReport returns true if any function in f fires, evaluating them as
+// an "or" composite would. It returns false for an empty f.
+func (f TriggerFns) ShouldReport(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	return compositeTriggerFn{factory: "or", fns: f, any: true}.Triggering(ctx, current, new, tc)
+}
+
+// ShouldReportAll returns true if every function in f fires, evaluating them
+// as an "and" composite would. Like ShouldReport, it returns false for an
+// empty f, rather than reporting on every answer.
+func (f TriggerFns) ShouldReportAll(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	if len(f) == 0 {
+		return false, nil
+	}
+	return compositeTriggerFn{factory: "and", fns: f}.Triggering(ctx, current, new, tc)
+}
+
+// Evaluate runs every function in f, and returns the names of those which
+// fire, or their factory names if they are unnamed, in f's order, e.g. for
+// logging which rules triggered a report.
+// If any function returns an error, Evaluate returns the first such error.
+func (f TriggerFns) Evaluate(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) ([]string, error) {
+	var names []string
+	var firstErr error
+	for _, tfn := range f {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fired, err := tfn.Triggering(ctx, current, new, tc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if fired {
+			names = append(names, triggerFnLabel(tfn))
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return names, nil
+}
+
+// makeInnerTriggerFns builds the functions described by a composite's params,
+// ordered by factory name.
+func makeInnerTriggerFns(factory string, params interface{}) (TriggerFns, error) {
+	m, err := objectParams(factory, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(m) == 0 {
+		return nil, errors.Errorf("%s requires at least one inner trigger function", factory)
+	}
+	fns := TriggerFns{}
+	for name, innerParams := range m {
+		tfn, err := makeTriggerFn(name, innerParams)
+		if err != nil {
+			return nil, err
+		}
+		fns = append(fns, tfn)
+	}
+	sort.Slice(fns, func(i, j int) bool { return fns[i].Factory() < fns[j].Factory() })
+	return fns, nil
+}
+
+// innerParameters is the inverse of makeInnerTriggerFns.
+func innerParameters(fns TriggerFns) map[string]interface{} {
+	params := make(map[string]interface{}, len(fns))
+	for _, tfn := range fns {
+		params[tfn.Factory()] = tfn.Parameters()
+	}
+	return params
+}
+
+// notTriggerFn fires exactly when its inner function doesn't, passing on any
+// error from it unchanged.
+type notTriggerFn struct {
+	inner TriggerFn
+}
+
+// notTriggerFnFactory expects the spec of the inner function, e.g.
+// {"type": "relativeThreshold", "params": 0.05}.
+func notTriggerFnFactory(params interface{}) (TriggerFn, error) {
+	var p triggerFnJSON
+	if err := decodeParams("not", params, &p); err != nil {
+		return nil, err
+	}
+	inner, err := makeTriggerFn(p.Type, p.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing not's inner trigger function")
+	}
+	return notTriggerFn{inner: inner}, nil
+}
+
+func (n notTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := n.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	return !fired, nil
+}
+
+func (n notTriggerFn) Clone() TriggerFn                { return notTriggerFn{inner: cloneTriggerFn(n.inner)} }
+func (n notTriggerFn) SetClock(clock utils.AfterNower) { TriggerFns{n.inner}.SetClock(clock) }
+func (n notTriggerFn) stateful() bool                  { return isStateful(n.inner) }
+func (n notTriggerFn) Factory() string                 { return "not" }
+func (n notTriggerFn) String() string                  { return "not(" + triggerFnString(n.inner) + ")" }
+
+func (n notTriggerFn) Parameters() interface{} {
+	return triggerFnJSON{Type: n.inner.Factory(), Params: n.inner.Parameters()}
+}
@@ -0,0 +1,279 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnd_FiresOnlyWhenAllInnerFire(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"and": {"relativeThreshold": 0.005, "absoluteThreshold": 0.01}}`))
+	require.Len(t, tfns, 1)
+	and := tfns[0]
+
+	tests := []struct {
+		name         string
+		current, new float64
+		expected     bool
+	}{
+		{"both hold", 1, 1.01, true},
+		{"only relative holds", 1, 1.009, false},
+		{"only absolute holds", 100, 100.02, false},
+		{"neither holds", 100, 100.001, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := and.Triggering(context.Background(), decimal.NewFromFloat(test.current), decimal.NewFromFloat(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fired)
+		})
+	}
+}
+
+func TestAnd_EvaluatesStatefulInnerFunctions(t *testing.T) {
+	// absoluteThreshold sorts first, so a short-circuiting AND would hide the
+	// answer which re-arms hysteresis whenever absoluteThreshold declines.
+	and := mustScanOne(t, `{"and": {"absoluteThreshold": 1, "hysteresis": {"upper": 0.02, "lower": 0.01}}}`)
+
+	answers := []float64{
+		102,   // both fire
+		101.5, // absoluteThreshold declines; hysteresis re-arms
+		99.9,  // both fire
+	}
+	assert.Equal(t, []bool{true, false, true}, pollSequence(t, and, 100, answers))
+}
+
+func TestAnd_ValueScanRoundTrip(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`{"and": {"relativeThreshold": 0.005, "absoluteThreshold": 0.01}}`))
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "and", scanned[0].Factory())
+	assert.Equal(t,
+		map[string]interface{}{"relativeThreshold": 0.005, "absoluteThreshold": 0.01},
+		scanned[0].Parameters())
+}
+
+func TestAnd_BadParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"and": 0.5}`))
+	assert.Error(t, tfns.Scan(`{"and": {}}`))
+	assert.Error(t, tfns.Scan(`{"and": {"squareDeviation": 0.5}}`))
+}
+
+func TestOr_FiresWhenAnyInnerFires(t *testing.T) {
+	lastReportedAt := time.Unix(1000000, 0)
+
+	tests := []struct {
+		name     string
+		new      int64
+		elapsed  time.Duration
+		expected bool
+	}{
+		{"big price move", 110, time.Minute, true},
+		{"stale report", 101, 2 * time.Hour, true},
+		{"neither", 101, time.Minute, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := new(mocks.AfterNower)
+			clock.On("Now").Return(lastReportedAt.Add(test.elapsed)).Maybe()
+
+			var tfns triggerfns.TriggerFns
+			require.NoError(t, tfns.Scan(`{"or": {"relativeThreshold": 0.05, "staleness": 3600}}`))
+			require.Len(t, tfns, 1)
+			tfns.SetClock(clock)
+
+			fired, err := tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(test.new), triggerfns.TriggerContext{LastReportedAt: lastReportedAt})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, fired)
+		})
+	}
+}
+
+func TestOr_PropagatesInnerError(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"or": {"relativeThreshold": 0.05, "staleness": 3600}}`))
+
+	// Without a last report time, staleness errors once relativeThreshold
+	// has declined to fire.
+	_, err := tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(101), triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "staleness requires the last report time")
+}
+
+func TestNot_InvertsInner(t *testing.T) {
+	inner := mustRelativeThreshold(t, 0.05)
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[{"type": "not", "params": {"type": "relativeThreshold", "params": 0.05}}]`))
+	not := tfns[0]
+	assert.Equal(t, "not(relativeThreshold(0.05))", tfns.String())
+
+	ctx, tc := context.Background(), triggerfns.TriggerContext{}
+	current := decimal.NewFromInt(100)
+	for _, answer := range []int64{100, 104, 105, 106, 95, 94, 0} {
+		new := decimal.NewFromInt(answer)
+		innerFired, err := inner.Triggering(ctx, current, new, tc)
+		require.NoError(t, err)
+		fired, err := not.Triggering(ctx, current, new, tc)
+		require.NoError(t, err)
+		assert.Equal(t, !innerFired, fired, "answer %d", answer)
+	}
+}
+
+func TestNot_PassesErrorsThrough(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[{"type": "not", "params": {"type": "staleness", "params": 60}}]`))
+
+	fired, err := tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(100),
+		triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "staleness requires the last report time")
+	assert.False(t, fired)
+}
+
+func TestNot_ComposesWithAnd(t *testing.T) {
+	// Fire on moves of at least 1, but only while they stay under 5%.
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"and": {
+		"absoluteThreshold": 1,
+		"not": {"type": "relativeThreshold", "params": 0.05}
+	}}`))
+
+	ctx, tc := context.Background(), triggerfns.TriggerContext{}
+	for _, test := range []struct {
+		current, new int64
+		expected     bool
+	}{
+		{100, 101, true},
+		{100, 110, false},
+		{10, 11, false},
+		{1000, 1010, true},
+		{1000, 1000, false},
+	} {
+		fired, err := tfns[0].Triggering(ctx, decimal.NewFromInt(test.current), decimal.NewFromInt(test.new), tc)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, fired, "%d to %d", test.current, test.new)
+	}
+}
+
+func TestNot_ValueScanRoundTrip(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`[{"type": "not", "params": {"type": "relativeThreshold", "params": 0.05}}]`))
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "not", "params": {"type": "relativeThreshold", "params": 0.05}}]}`,
+		string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, original.Equals(scanned))
+
+	assert.Error(t, scanned.Scan(`{"not": 0.05}`))
+	assert.Error(t, scanned.Scan(`{"not": {"type": "absoluteThreshold"}}`))
+	assert.Error(t, scanned.Scan(`{"not": {"type": "squareDeviation", "params": 4}}`))
+}
+
+func TestTriggerFns_ShouldReport(t *testing.T) {
+	ctx, tc := context.Background(), triggerfns.TriggerContext{}
+	relative, absolute := mustRelativeThreshold(t, 0.05), mustAbsoluteThreshold(t, 2)
+
+	tests := []struct {
+		name             string
+		tfns             triggerfns.TriggerFns
+		new              int64
+		wantAny, wantAll bool
+	}{
+		{"empty", triggerfns.TriggerFns{}, 200, false, false},
+		{"nil", nil, 200, false, false},
+		{"single, firing", triggerfns.TriggerFns{relative}, 105, true, true},
+		{"single, not firing", triggerfns.TriggerFns{relative}, 104, false, false},
+		{"mixed", triggerfns.TriggerFns{relative, absolute}, 103, true, false},
+		{"all firing", triggerfns.TriggerFns{relative, absolute}, 106, true, true},
+		{"none firing", triggerfns.TriggerFns{relative, absolute}, 101, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.NewFromInt(100), decimal.NewFromInt(test.new)
+			fired, err := test.tfns.ShouldReport(ctx, current, new, tc)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantAny, fired, "ShouldReport")
+			fired, err = test.tfns.ShouldReportAll(ctx, current, new, tc)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantAll, fired, "ShouldReportAll")
+		})
+	}
+}
+
+func TestTriggerFns_ShouldReport_ReturnsFirstError(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.05, "staleness": 60}`))
+	ctx, tc := context.Background(), triggerfns.TriggerContext{}
+
+	_, err := tfns.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(101), tc)
+	assert.EqualError(t, err, "staleness requires the last report time")
+
+	// relativeThreshold fires first, settling the result.
+	fired, err := tfns.ShouldReport(ctx, decimal.NewFromInt(100), decimal.NewFromInt(110), tc)
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestTriggerFns_Evaluate(t *testing.T) {
+	lastReportedAt := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(lastReportedAt.Add(2 * time.Hour))
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "absoluteThreshold", "params": 5},
+		{"type": "relativeThreshold", "params": 0.02},
+		{"type": "staleness", "params": 3600}
+	]`))
+	tfns.SetClock(clock)
+
+	tests := []struct {
+		name           string
+		new            int64
+		lastReportedAt time.Time
+		want           []string
+	}{
+		{"none", 101, lastReportedAt.Add(time.Hour + time.Second), nil},
+		{"relative only", 103, lastReportedAt.Add(time.Hour + time.Second), []string{"relativeThreshold"}},
+		{"staleness only", 100, lastReportedAt, []string{"staleness"}},
+		{"relative and staleness", 103, lastReportedAt, []string{"relativeThreshold", "staleness"}},
+		{"all", 110, lastReportedAt, []string{"absoluteThreshold", "relativeThreshold", "staleness"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names, err := tfns.Evaluate(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(test.new),
+				triggerfns.TriggerContext{LastReportedAt: test.lastReportedAt})
+			require.NoError(t, err)
+			assert.Equal(t, test.want, names)
+		})
+	}
+}
+
+func TestTriggerFns_Evaluate_Error(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.05, "staleness": 60}`))
+
+	names, err := tfns.Evaluate(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(110),
+		triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "staleness requires the last report time")
+	assert.Nil(t, names)
+}
@@ -0,0 +1,58 @@
+package triggerfns_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTriggering_Concurrent calls Triggering on shared instances from several
+// goroutines at once. It finds nothing without -race; run it with -race after
+// adding or changing a stateful trigger function.
+func TestTriggering_Concurrent(t *testing.T) {
+	specs := []string{
+		`{"relativeThreshold": 0.005}`,
+		`{"relativeBps": 25}`,
+		`{"hysteresis": {"upper": 0.01, "lower": 0.002}}`,
+		`{"ewmaThreshold": {"alpha": 0.2, "threshold": 0.005}}`,
+		`{"zscore": {"windowSize": 8, "sigma": 2}}`,
+		`{"adaptiveThreshold": {"base": 0.005, "multiplier": 2, "windowSize": 8}}`,
+		`{"maCrossover": {"shortWindow": 3, "longWindow": 8}}`,
+		`{"cooldown": {"period": "1ns", "triggerFn": {"type": "ewmaThreshold", "params": {"alpha": 0.2, "threshold": 0.001}}}}`,
+		`{"and": {"hysteresis": {"upper": 0.01, "lower": 0.002}, "zscore": {"windowSize": 4, "sigma": 1}}}`,
+		`{"quorum": {"threshold": 1, "triggers": {"ewmaThreshold": {"alpha": 0.5, "threshold": 0.002}, "maCrossover": {"shortWindow": 1, "longWindow": 3}}}}`,
+	}
+	prices := priceWalk(benchmarkSeed, 256, 1234.5678)
+	tc := triggerfns.TriggerContext{LastReportedAt: time.Now(), BlockNumber: 100, LastReportedBlock: 90}
+
+	for _, spec := range specs {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(spec), spec)
+		metered, err := tfns.WithMetrics(prometheus.NewRegistry())
+		require.NoError(t, err)
+		for _, shared := range []triggerfns.TriggerFns{tfns, metered.WithAudit(&recordingSink{})} {
+			t.Run(shared.String(), func(t *testing.T) {
+				var wg sync.WaitGroup
+				for g := 0; g < 8; g++ {
+					wg.Add(1)
+					go func(g int) {
+						defer wg.Done()
+						for i := range prices {
+							new := prices[(i+g*31)%len(prices)]
+							_, err := shared.ShouldReport(context.Background(), prices[0], new, tc)
+							assert.NoError(t, err)
+						}
+					}(g)
+				}
+				wg.Wait()
+			})
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package triggerfns
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+)
+
+// triggerFnConflict describes two trigger functions which contradict each
+// other when combined in the same set or composite.
+type triggerFnConflict struct {
+	a, b   string
+	reason string
+}
+
+// triggerFnConflicts lists the combinations checkConflicts rejects. Add to it
+// as other contradictory pairs are found.
+var triggerFnConflicts = []triggerFnConflict{
+	{"always", "never", "one forces every report and the other disables reporting"},
+}
+
+// rejectConflicts is set to 1 when Scan should fail on conflicting functions.
+var rejectConflicts int32
+
+// SetRejectConflicts controls whether Scan returns an error for a value which
+// combines conflicting trigger functions, such as always and never. Validate
+// always reports them. It's off by default, so that specs already stored
+// still load.
+func SetRejectConflicts(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&rejectConflicts, v)
+}
+
+func useRejectConflicts() bool { return atomic.LoadInt32(&rejectConflicts) == 1 }
+
+// checkConflicts returns an error, wrapping ErrConflictingTriggerFns, for
+// each pair in triggerFnConflicts which fns, found at path, combines, and
+// likewise for the functions within each composite in fns.
+func checkConflicts(path string, fns TriggerFns) error {
+	present := make(map[string]bool, len(fns))
+	var merr error
+	for _, tfn := range fns {
+		tfn = unwrapMetered(tfn)
+		present[tfn.Factory()] = true
+		if c, ok := tfn.(compositeTriggerFn); ok {
+			merr = multierr.Append(merr, checkConflicts(path+"."+c.factory, c.fns))
+		}
+	}
+	for _, conflict := range triggerFnConflicts {
+		if present[conflict.a] && present[conflict.b] {
+			merr = multierr.Append(merr, fmt.Errorf("%s: %w: %s and %s, as %s",
+				path, ErrConflictingTriggerFns, conflict.a, conflict.b, conflict.reason))
+		}
+	}
+	return merr
+}
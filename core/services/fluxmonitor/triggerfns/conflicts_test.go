@@ -0,0 +1,53 @@
+package triggerfns_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestConflicts_AlwaysAndNever(t *testing.T) {
+	const spec = `{"always": null, "never": null, "or": {"always": null, "never": {}}}`
+
+	// Scan accepts conflicts unless told otherwise...
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(spec))
+
+	// ...but Validate reports each, at every level.
+	errs := multierr.Errors(tfns.Validate())
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, triggerfns.ErrConflictingTriggerFns), err)
+		assert.False(t, triggerfns.IsWarning(err))
+	}
+	assert.EqualError(t, errs[0], "triggerFns.or: conflicting trigger functions: always and never, "+
+		"as one forces every report and the other disables reporting")
+	assert.EqualError(t, errs[1], "triggerFns: conflicting trigger functions: always and never, "+
+		"as one forces every report and the other disables reporting")
+
+	triggerfns.SetRejectConflicts(true)
+	defer triggerfns.SetRejectConflicts(false)
+	err := tfns.Scan(`{"always": null, "never": null}`)
+	assert.True(t, errors.Is(err, triggerfns.ErrConflictingTriggerFns), err)
+	err = tfns.Scan(`{"and": {"always": null, "never": null}}`)
+	assert.True(t, errors.Is(err, triggerfns.ErrConflictingTriggerFns), err)
+}
+
+func TestConflicts_Benign(t *testing.T) {
+	triggerfns.SetRejectConflicts(true)
+	defer triggerfns.SetRejectConflicts(false)
+
+	var tfns triggerfns.TriggerFns
+	// always and never in separate composites don't conflict.
+	require.NoError(t, tfns.Scan(`{
+		"relativeThreshold": 0.01,
+		"never": null,
+		"or": {"always": null, "staleness": 60}
+	}`))
+	assert.NoError(t, tfns.Validate())
+}
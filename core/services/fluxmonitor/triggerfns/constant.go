@@ -0,0 +1,40 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// constantTriggerFn always or never fires, whatever the answers. always
+// forces a report every round and never disables reporting, without having
+// to touch any other trigger function in a spec.
+type constantTriggerFn struct {
+	factory string
+	fires   bool
+}
+
+// alwaysFactory and neverFactory take no params, so expect null or {}.
+func alwaysFactory(params interface{}) (TriggerFn, error) {
+	return constantFactory("always", true, params)
+}
+
+func neverFactory(params interface{}) (TriggerFn, error) {
+	return constantFactory("never", false, params)
+}
+
+func constantFactory(factory string, fires bool, params interface{}) (TriggerFn, error) {
+	if m, ok := params.(map[string]interface{}); params != nil && !(ok && len(m) == 0) {
+		return nil, errors.Errorf("%s takes no params, got %v", factory, params)
+	}
+	return constantTriggerFn{factory: factory, fires: fires}, nil
+}
+
+func (c constantTriggerFn) Triggering(_ context.Context, _, _ decimal.Decimal, _ TriggerContext) (bool, error) {
+	return c.fires, nil
+}
+
+func (c constantTriggerFn) Factory() string         { return c.factory }
+func (c constantTriggerFn) Parameters() interface{} { return nil }
+func (c constantTriggerFn) ParamSchema() string     { return triggerFnSchemas[c.factory] }
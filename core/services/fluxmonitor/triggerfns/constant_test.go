@@ -0,0 +1,53 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlwaysAndNever(t *testing.T) {
+	always := mustScanOne(t, `[{"type": "always", "params": null}]`)
+	never := mustScanOne(t, `{"never": {}}`)
+
+	answers := []struct{ current, new string }{
+		{"100", "100"}, {"100", "1000"}, {"0", "0"}, {"-5", "5"},
+	}
+	for _, a := range answers {
+		current, new := decimal.RequireFromString(a.current), decimal.RequireFromString(a.new)
+		fired, err := always.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.True(t, fired, "always, %s to %s", a.current, a.new)
+		fired, err = never.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.False(t, fired, "never, %s to %s", a.current, a.new)
+	}
+}
+
+func TestAlwaysAndNever_ValueScanRoundTrip(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`{"always": null, "never": null, "and": {"never": {}, "relativeThreshold": 0.01}}`))
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [
+		{"type": "always", "params": null},
+		{"type": "and", "params": {"never": null, "relativeThreshold": 0.01}},
+		{"type": "never", "params": null}
+	]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, original.Equals(scanned))
+}
+
+func TestAlwaysAndNever_RejectParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"always": true}`))
+	assert.Error(t, tfns.Scan(`{"never": {"fires": false}}`))
+}
@@ -0,0 +1,126 @@
+package triggerfns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	// Like the composites, cooldown builds its inner function through
+	// makeTriggerFn.
+	triggerFnFactories["cooldown"] = FactoryFunc(cooldownFactory)
+}
+
+// cooldownParams are the params of cooldown, e.g.
+// {"period": "5m", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type cooldownParams struct {
+	Period    duration      `json:"period"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// duration is a non-negative time.Duration, which is written in JSON in the
+// form "72h3m0.5s".
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(input []byte) error {
+	var txt string
+	if err := json.Unmarshal(input, &txt); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(txt)
+	if err != nil {
+		return err
+	}
+	if v < 0 {
+		return fmt.Errorf("cannot make negative time duration: %s", v)
+	}
+	*d = duration(v)
+	return nil
+}
+
+// cooldownTriggerFn fires when its inner function does, unless it last fired
+// less than period ago. The inner function is evaluated either way, so that a
+// stateful one sees every answer.
+type cooldownTriggerFn struct {
+	period time.Duration
+	inner  TriggerFn
+	clock  utils.AfterNower
+
+	mu        sync.Mutex
+	lastFired time.Time
+}
+
+func cooldownFactory(params interface{}) (TriggerFn, error) {
+	var p cooldownParams
+	if err := decodeParams("cooldown", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Period == 0 {
+		return nil, errors.New("cooldown requires a positive period")
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing cooldown's inner trigger function")
+	}
+	return &cooldownTriggerFn{
+		period: time.Duration(p.Period),
+		inner:  inner,
+		clock:  utils.Clock{},
+	}, nil
+}
+
+func (c *cooldownTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := c.inner.Triggering(ctx, current, new, tc)
+	if err != nil || !fired {
+		return false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if !c.lastFired.IsZero() && now.Sub(c.lastFired) < c.period {
+		return false, nil
+	}
+	c.lastFired = now
+	return true, nil
+}
+
+// SetClock sets the clock c measures its period with, and passes it on to the
+// inner function.
+func (c *cooldownTriggerFn) SetClock(clock utils.AfterNower) {
+	c.mu.Lock()
+	c.clock = clock
+	c.mu.Unlock()
+	TriggerFns{c.inner}.SetClock(clock)
+}
+
+// Clone returns a cooldown which has never fired, wrapping a clone of c's
+// inner function.
+func (c *cooldownTriggerFn) Clone() TriggerFn {
+	return &cooldownTriggerFn{period: c.period, inner: cloneTriggerFn(c.inner), clock: c.clock}
+}
+
+func (c *cooldownTriggerFn) stateful() bool  { return isStateful(c.inner) }
+func (c *cooldownTriggerFn) Factory() string { return "cooldown" }
+
+func (c *cooldownTriggerFn) Parameters() interface{} {
+	return cooldownParams{
+		Period:    duration(c.period),
+		TriggerFn: triggerFnJSON{Type: c.inner.Factory(), Params: c.inner.Parameters()},
+	}
+}
+
+func (c *cooldownTriggerFn) String() string {
+	return fmt.Sprintf("cooldown(%s, %s)", c.period, triggerFnString(c.inner))
+}
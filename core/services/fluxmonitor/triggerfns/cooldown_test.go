@@ -0,0 +1,112 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cooldownSpec = `[{"type": "cooldown", "params": {
+	"period": "5m",
+	"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+}}]`
+
+// pollAt evaluates tfns[0] on a move its inner function always fires on, at
+// each of the given offsets from start.
+func pollAt(t *testing.T, tfns triggerfns.TriggerFns, offsets []time.Duration) []bool {
+	t.Helper()
+	start := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	tfns.SetClock(clock)
+	fired := make([]bool, len(offsets))
+	for i, offset := range offsets {
+		clock.On("Now").Return(start.Add(offset)).Once()
+		var err error
+		fired[i], err = tfns[0].Triggering(context.Background(),
+			decimal.NewFromInt(100), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+	}
+	clock.AssertExpectations(t)
+	return fired
+}
+
+func TestCooldown_AllowsOneFirePerPeriod(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(cooldownSpec))
+
+	fired := pollAt(t, tfns, []time.Duration{
+		0,
+		time.Minute,
+		5*time.Minute - time.Second,
+		5 * time.Minute,
+		6 * time.Minute,
+		11 * time.Minute,
+	})
+	assert.Equal(t, []bool{true, false, false, true, false, true}, fired)
+}
+
+func TestCooldown_DoesNotFireWithoutInner(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(cooldownSpec))
+
+	// The inner function doesn't fire, so the clock isn't consulted.
+	fired, err := tfns[0].Triggering(context.Background(),
+		decimal.NewFromInt(100), decimal.NewFromInt(100), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+}
+
+func TestCooldown_Clone(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(cooldownSpec))
+	assert.Equal(t, []bool{true}, pollAt(t, tfns, []time.Duration{0}))
+
+	// The clone hasn't fired, so it isn't cooling down.
+	assert.Equal(t, []bool{true}, pollAt(t, tfns.Clone(), []time.Duration{time.Minute}))
+}
+
+func TestCooldown_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(cooldownSpec))
+	assert.Equal(t, "cooldown(5m0s, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "cooldown", "params": {
+		"period": "5m0s",
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, tfns.Equals(scanned))
+	assert.NoError(t, scanned.Validate())
+}
+
+func TestCooldown_BadParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"not an object", `{"cooldown": 300}`},
+		{"missing period", `{"cooldown": {"triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`},
+		{"zero period", `{"cooldown": {"period": "0s", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`},
+		{"bad period", `{"cooldown": {"period": "soon", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`},
+		{"negative period", `{"cooldown": {"period": "-5m", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`},
+		{"missing triggerFn", `{"cooldown": {"period": "5m"}}`},
+		{"unknown inner", `{"cooldown": {"period": "5m", "triggerFn": {"type": "squareDeviation", "params": 4}}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.params))
+		})
+	}
+}
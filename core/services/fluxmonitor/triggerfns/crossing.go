@@ -0,0 +1,48 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// crossingTriggerFn fires when the answer crosses target, so a feed can
+// report exactly when the price passes a level that matters to its
+// consumers, however small the move.
+//
+// Reaching target counts as crossing it. Moving away from an answer which
+// was exactly target does not, since that answer was itself reported as a
+// crossing.
+type crossingTriggerFn struct {
+	target        float64
+	targetDecimal decimal.Decimal
+}
+
+// crossingFactory expects the target as a bare number or as
+// {"target": number}.
+func crossingFactory(params interface{}) (TriggerFn, error) {
+	var p struct {
+		Target float64 `json:"target"`
+	}
+	if err := decodeParams("crossing", params, &p); err != nil {
+		return nil, err
+	}
+	if math.IsNaN(p.Target) || math.IsInf(p.Target, 0) {
+		return nil, errors.Errorf("crossing requires a finite target, got %v", p.Target)
+	}
+	return crossingTriggerFn{target: p.Target, targetDecimal: decimal.NewFromFloat(p.Target)}, nil
+}
+
+func (c crossingTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	before := current.Cmp(c.targetDecimal)
+	after := new.Cmp(c.targetDecimal)
+	if after == 0 {
+		return before != 0, nil
+	}
+	return before == -after, nil
+}
+
+func (c crossingTriggerFn) Factory() string         { return "crossing" }
+func (c crossingTriggerFn) Parameters() interface{} { return c.target }
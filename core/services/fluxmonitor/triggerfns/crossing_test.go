@@ -0,0 +1,48 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossing(t *testing.T) {
+	tfn := mustScanOne(t, `{"crossing": 1500}`)
+	assert.Equal(t, float64(1500), tfn.Parameters())
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"upward cross", "1499.99", "1500.01", true},
+		{"downward cross", "1510", "1490", true},
+		{"touching from below", "1490", "1500", true},
+		{"touching from above", "1510", "1500", true},
+		{"leaving the level", "1500", "1510", false},
+		{"resting on the level", "1500", "1500", false},
+		{"staying below", "1400", "1499.99", false},
+		{"staying above", "1600", "1500.01", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current), decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestCrossing_Params(t *testing.T) {
+	tfn := mustScanOne(t, `[{"type": "crossing", "params": {"target": -2.5}}]`)
+	assert.Equal(t, -2.5, tfn.Parameters())
+
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"crossing": "1500"}`))
+	assert.Error(t, tfns.Scan(`{"crossing": {}}`))
+}
@@ -0,0 +1,101 @@
+package triggerfns
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// maCrossoverParams are the params of maCrossover, e.g.
+// {"shortWindow": 5, "longWindow": 20}.
+type maCrossoverParams struct {
+	ShortWindow int `json:"shortWindow"`
+	LongWindow  int `json:"longWindow"`
+}
+
+// maCrossoverTriggerFn fires when the moving average of the last shortWindow
+// answers it was shown crosses that of the last longWindow, in either
+// direction. It records every answer, fired on or not, and never fires until
+// it has seen longWindow answers. An average touching the other without
+// crossing it doesn't fire.
+type maCrossoverTriggerFn struct {
+	params maCrossoverParams
+
+	mu     sync.Mutex
+	window []float64 // ring buffer of the most recent longWindow answers
+	next   int       // index in window of the oldest answer, once it is full
+	// above is 1 if the short average was last above the long one, -1 if it
+	// was last below, and 0 until they have been compared and differed.
+	above int
+}
+
+func maCrossoverFactory(params interface{}) (TriggerFn, error) {
+	var p maCrossoverParams
+	if err := decodeParams("maCrossover", params, &p); err != nil {
+		return nil, err
+	}
+	if p.ShortWindow < 1 {
+		return nil, errors.Errorf("maCrossover requires a shortWindow of at least 1, got %d", p.ShortWindow)
+	}
+	if p.LongWindow <= p.ShortWindow {
+		return nil, errors.Errorf("maCrossover requires a longWindow greater than its shortWindow of %d, got %d",
+			p.ShortWindow, p.LongWindow)
+	}
+	return &maCrossoverTriggerFn{params: p}, nil
+}
+
+func (m *maCrossoverTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	answer, _ := new.Float64()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.window) < m.params.LongWindow {
+		m.window = append(m.window, answer)
+		if len(m.window) < m.params.LongWindow {
+			return false, nil
+		}
+	} else {
+		m.window[m.next] = answer
+		m.next = (m.next + 1) % len(m.window)
+	}
+
+	var short, long float64
+	for i := 0; i < len(m.window); i++ {
+		// The window's answers from oldest to newest start at m.next.
+		x := m.window[(m.next+i)%len(m.window)]
+		long += x
+		if i >= len(m.window)-m.params.ShortWindow {
+			short += x
+		}
+	}
+	short /= float64(m.params.ShortWindow)
+	long /= float64(m.params.LongWindow)
+
+	above := 0
+	if short > long {
+		above = 1
+	} else if short < long {
+		above = -1
+	}
+	if above == 0 {
+		return false, nil
+	}
+	fired := m.above != 0 && above != m.above
+	m.above = above
+	return fired, nil
+}
+
+// Clone returns an maCrossover function with m's params and no history.
+func (m *maCrossoverTriggerFn) Clone() TriggerFn { return &maCrossoverTriggerFn{params: m.params} }
+
+func (m *maCrossoverTriggerFn) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.window, m.next, m.above = nil, 0, 0
+}
+
+func (m *maCrossoverTriggerFn) stateful() bool          { return true }
+func (m *maCrossoverTriggerFn) Factory() string         { return "maCrossover" }
+func (m *maCrossoverTriggerFn) Parameters() interface{} { return m.params }
+func (m *maCrossoverTriggerFn) ParamSchema() string     { return triggerFnSchemas["maCrossover"] }
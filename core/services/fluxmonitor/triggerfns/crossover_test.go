@@ -0,0 +1,73 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMACrossover_GoldenCross(t *testing.T) {
+	tfn := mustScanOne(t, `{"maCrossover": {"shortWindow": 2, "longWindow": 4}}`)
+
+	// A downtrend turning into an uptrend. The short average first rises
+	// above the long one on the eighth answer, 7.
+	answers := []float64{10, 9, 8, 7, 6, 5, 6, 7, 8, 9, 10, 11}
+	expected := make([]bool, len(answers))
+	expected[7] = true
+	assert.Equal(t, expected, feed(t, tfn, answers))
+}
+
+func TestMACrossover_DeathCross(t *testing.T) {
+	tfn := mustScanOne(t, `{"maCrossover": {"shortWindow": 2, "longWindow": 4}}`)
+
+	answers := []float64{5, 6, 7, 8, 9, 10, 9, 8, 7, 6}
+	expected := make([]bool, len(answers))
+	expected[7] = true
+	assert.Equal(t, expected, feed(t, tfn, answers))
+}
+
+func TestMACrossover_DoesNotFireWhileWarmingUp(t *testing.T) {
+	tfn := mustScanOne(t, `{"maCrossover": {"shortWindow": 1, "longWindow": 3}}`)
+
+	// The short average crosses the long one on the second and third
+	// answers, before the long window is full.
+	assert.Equal(t, []bool{false, false, false}, feed(t, tfn, []float64{10, 1, 20}))
+	assert.Equal(t, []bool{true}, feed(t, tfn, []float64{1}))
+}
+
+func TestMACrossover_TouchingDoesNotFire(t *testing.T) {
+	tfn := mustScanOne(t, `{"maCrossover": {"shortWindow": 1, "longWindow": 3}}`)
+
+	// Below the long average, level with it, then below again.
+	assert.Equal(t, []bool{false, false, false, false, false}, feed(t, tfn, []float64{3, 2, 1, 1.5, 1}))
+	// Level again, then above.
+	assert.Equal(t, []bool{false, true}, feed(t, tfn, []float64{1.25, 5}))
+}
+
+func TestMACrossover_ResetAndClone(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"maCrossover": {"shortWindow": 1, "longWindow": 2}}`))
+	feed(t, tfns[0], []float64{10, 1})
+
+	clone := tfns.Clone()
+	tfns.ResetAll()
+	for _, tfn := range []triggerfns.TriggerFn{tfns[0], clone[0]} {
+		assert.Equal(t, []bool{false, false, false}, feed(t, tfn, []float64{1, 10, 11}))
+	}
+}
+
+func TestMACrossover_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"maCrossover": {"shortWindow": 0, "longWindow": 4}}`,
+		`{"maCrossover": {"shortWindow": 4, "longWindow": 4}}`,
+		`{"maCrossover": {"shortWindow": 5, "longWindow": 4}}`,
+		`{"maCrossover": {"shortWindow": 2}}`,
+		`{"maCrossover": 3}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
@@ -0,0 +1,99 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["debounce"] = FactoryFunc(debounceFactory)
+}
+
+// debounceParams are the params of debounce, e.g.
+// {"count": 3, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type debounceParams struct {
+	Count     uint32        `json:"count"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// debounceTriggerFn fires when its inner function has fired on count
+// consecutive evaluations, filtering out moves which last a single tick. Any
+// evaluation on which the inner function doesn't fire starts the count again,
+// as does firing, so that a move which persists fires every count ticks. An
+// evaluation which returns an error leaves the count as it was.
+type debounceTriggerFn struct {
+	count uint32
+	inner TriggerFn
+
+	mu  sync.Mutex
+	run uint32
+}
+
+func debounceFactory(params interface{}) (TriggerFn, error) {
+	var p debounceParams
+	if err := decodeParams("debounce", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Count == 0 {
+		return nil, errors.New("debounce requires a positive count")
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing debounce's inner trigger function")
+	}
+	return &debounceTriggerFn{count: p.Count, inner: inner}, nil
+}
+
+func (d *debounceTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := d.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !fired {
+		d.run = 0
+		return false, nil
+	}
+	d.run++
+	if d.run < d.count {
+		return false, nil
+	}
+	d.run = 0
+	return true, nil
+}
+
+// Clone returns a debounce which has seen no evaluations, wrapping a clone of
+// d's inner function.
+func (d *debounceTriggerFn) Clone() TriggerFn {
+	return &debounceTriggerFn{count: d.count, inner: cloneTriggerFn(d.inner)}
+}
+
+// Reset starts d's count again, and resets its inner function.
+func (d *debounceTriggerFn) Reset() {
+	d.mu.Lock()
+	d.run = 0
+	d.mu.Unlock()
+	TriggerFns{d.inner}.ResetAll()
+}
+
+func (d *debounceTriggerFn) Parameters() interface{} {
+	return debounceParams{
+		Count:     d.count,
+		TriggerFn: triggerFnJSON{Type: d.inner.Factory(), Params: d.inner.Parameters()},
+	}
+}
+
+func (d *debounceTriggerFn) SetClock(clock utils.AfterNower) { TriggerFns{d.inner}.SetClock(clock) }
+func (d *debounceTriggerFn) stateful() bool                  { return true }
+func (d *debounceTriggerFn) Factory() string                 { return "debounce" }
+func (d *debounceTriggerFn) ParamSchema() string             { return triggerFnSchemas["debounce"] }
+func (d *debounceTriggerFn) String() string {
+	return fmt.Sprintf("debounce(%d, %s)", d.count, triggerFnString(d.inner))
+}
@@ -0,0 +1,99 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const debounceSpec = `{"debounce": {"count": 3, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`
+
+// debounceTicks evaluates tfn once per answer, each against a current answer
+// of 100, so that answers of 101 or more are deviations.
+func debounceTicks(t *testing.T, tfn triggerfns.TriggerFn, answers ...int64) []bool {
+	t.Helper()
+	fired := make([]bool, len(answers))
+	for i, answer := range answers {
+		var err error
+		fired[i], err = tfn.Triggering(context.Background(),
+			decimal.NewFromInt(100), decimal.NewFromInt(answer), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+	}
+	return fired
+}
+
+func TestDebounce(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(debounceSpec))
+
+	fired := debounceTicks(t, tfns[0],
+		// Interrupted runs never reach the count.
+		105, 105, 100,
+		105, 100,
+		// An uninterrupted run fires on its third deviation...
+		105, 106, 107,
+		// ...and then on every third one after that, while it persists.
+		108, 109, 110,
+		100,
+	)
+	assert.Equal(t, []bool{
+		false, false, false,
+		false, false,
+		false, false, true,
+		false, false, true,
+		false,
+	}, fired)
+}
+
+func TestDebounce_CountOfOne(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"debounce": {"count": 1, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`))
+	assert.Equal(t, []bool{true, false, true, true}, debounceTicks(t, tfns[0], 105, 100, 105, 105))
+}
+
+func TestDebounce_CloneAndReset(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(debounceSpec))
+	assert.Equal(t, []bool{false, false}, debounceTicks(t, tfns[0], 105, 105))
+
+	// The clone's count starts again.
+	clone := tfns.Clone()
+	assert.Equal(t, []bool{false, false, true}, debounceTicks(t, clone[0], 105, 105, 105))
+
+	tfns.ResetAll()
+	assert.Equal(t, []bool{false, false, true}, debounceTicks(t, tfns[0], 105, 105, 105))
+}
+
+func TestDebounce_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(debounceSpec))
+	assert.Equal(t, "debounce(3, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "debounce", "params": {
+		"count": 3,
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}]}`, string(value.([]byte)))
+
+	var rescanned triggerfns.TriggerFns
+	require.NoError(t, rescanned.Scan(value))
+	assert.True(t, tfns.Equals(rescanned))
+}
+
+func TestDebounce_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"debounce": 3}`,
+		`{"debounce": {"count": 0, "triggerFn": {"type": "staleness", "params": 60}}}`,
+		`{"debounce": {"count": -1, "triggerFn": {"type": "staleness", "params": 60}}}`,
+		`{"debounce": {"count": 3, "triggerFn": {"type": "nonexistent", "params": 60}}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
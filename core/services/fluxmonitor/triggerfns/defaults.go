@@ -0,0 +1,37 @@
+package triggerfns
+
+import "sync/atomic"
+
+// defaultOnEmpty is set to 1 when Scan should substitute DefaultTriggerFns
+// for an empty value.
+var defaultOnEmpty int32
+
+// DefaultTriggerFns returns the trigger functions used for job specs which
+// don't list any, if SetDefaultOnEmpty has enabled them: a relativeThreshold
+// of 0.5%.
+func DefaultTriggerFns() TriggerFns {
+	return TriggerFns{RelativeThreshold(0.005)}
+}
+
+// SetDefaultOnEmpty controls whether Scan returns DefaultTriggerFns, rather
+// than an empty set which never reports, for a value with no trigger
+// functions. It is off unless the node enables it at startup from
+// FLUX_MONITOR_DEFAULT_TRIGGER_FNS.
+func SetDefaultOnEmpty(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&defaultOnEmpty, v)
+}
+
+func useDefaultOnEmpty() bool { return atomic.LoadInt32(&defaultOnEmpty) == 1 }
+
+// mustTriggerFn returns tfn, panicking on err. It's only for trigger
+// functions built from constant params.
+func mustTriggerFn(tfn TriggerFn, err error) TriggerFn {
+	if err != nil {
+		panic(err)
+	}
+	return tfn
+}
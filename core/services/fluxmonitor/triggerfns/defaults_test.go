@@ -0,0 +1,43 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTriggerFns(t *testing.T) {
+	assert.Equal(t, "relativeThreshold(0.005)", triggerfns.DefaultTriggerFns().String())
+	assert.NoError(t, triggerfns.DefaultTriggerFns().Validate())
+}
+
+func TestTriggerFns_Scan_EmptyValue(t *testing.T) {
+	emptyValues := []interface{}{nil, "", []byte{}, `[]`, `{}`}
+
+	t.Run("defaults disabled", func(t *testing.T) {
+		for _, value := range emptyValues {
+			tfns := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.5)}
+			require.NoError(t, tfns.Scan(value), "%#v", value)
+			assert.Empty(t, tfns, "%#v", value)
+		}
+	})
+
+	t.Run("defaults enabled", func(t *testing.T) {
+		triggerfns.SetDefaultOnEmpty(true)
+		defer triggerfns.SetDefaultOnEmpty(false)
+
+		for _, value := range emptyValues {
+			var tfns triggerfns.TriggerFns
+			require.NoError(t, tfns.Scan(value), "%#v", value)
+			assert.True(t, triggerfns.DefaultTriggerFns().Equals(tfns), "%#v", value)
+		}
+
+		// Functions which are listed replace the defaults.
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(`{"absoluteThreshold": 1}`))
+		assert.Equal(t, "absoluteThreshold(1)", tfns.String())
+	})
+}
@@ -0,0 +1,43 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// ZeroCurrentDeviation is what RelativeDeviation returns when new moves away
+// from a zero current answer, whether up or down. Such a move is no finite
+// fraction of current, so it is measured against new instead: the whole of
+// new is the deviation, 100%. Relative thresholds above 1 therefore never
+// fire on a move away from zero.
+var ZeroCurrentDeviation = decimal.NewFromInt(1)
+
+// RelativeDeviation returns how far new is from current, as a fraction of
+// current, rounded to decimal.DivisionPrecision places. It returns zero if
+// both are zero, and ZeroCurrentDeviation if only current is. A move across
+// zero, where current and new have opposite signs, always exceeds 100%, as
+// it covers the whole of current and then new on the other side.
+func RelativeDeviation(current, new decimal.Decimal) decimal.Decimal {
+	if current.IsZero() {
+		if new.IsZero() {
+			return decimal.Zero
+		}
+		return ZeroCurrentDeviation
+	}
+	return AbsoluteDeviation(current, new).Div(current.Abs())
+}
+
+// MidpointDeviation returns how far new is from current, as a fraction of the
+// mean of their magnitudes, rounded to decimal.DivisionPrecision places.
+// Unlike RelativeDeviation it is symmetric in current and new, and needs no
+// special case for a zero current. It returns zero if both are zero, and
+// 2, 200%, for any move to, from or across zero.
+func MidpointDeviation(current, new decimal.Decimal) decimal.Decimal {
+	magnitudes := current.Abs().Add(new.Abs())
+	if magnitudes.IsZero() {
+		return decimal.Zero
+	}
+	return AbsoluteDeviation(current, new).Mul(decimal.NewFromInt(2)).Div(magnitudes)
+}
+
+// AbsoluteDeviation returns how far new is from current.
+func AbsoluteDeviation(current, new decimal.Decimal) decimal.Decimal {
+	return new.Sub(current).Abs()
+}
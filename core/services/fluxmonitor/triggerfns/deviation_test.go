@@ -0,0 +1,74 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeDeviation(t *testing.T) {
+	tests := []struct {
+		name         string
+		current, new string
+		expected     decimal.Decimal
+	}{
+		{"unchanged", "100", "100", decimal.Zero},
+		{"rise", "100", "100.73", decimal.RequireFromString("0.0073")},
+		{"fall", "100", "99.5", decimal.RequireFromString("0.005")},
+		{"negative current", "-200", "-201", decimal.RequireFromString("0.005")},
+		{"zero current and new", "0", "0", decimal.Zero},
+		{"zero current, positive new", "0", "0.01", decimal.NewFromInt(1)},
+		{"zero current, negative new", "0", "-250", decimal.NewFromInt(1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := triggerfns.RelativeDeviation(decimal.RequireFromString(test.current), decimal.RequireFromString(test.new))
+			assert.True(t, test.expected.Equal(actual), "expected %s, got %s", test.expected, actual)
+		})
+	}
+	assert.True(t, triggerfns.ZeroCurrentDeviation.Equal(decimal.NewFromInt(1)))
+}
+
+// TestRelativeThreshold_ZeroCurrent checks that relativeThreshold fires on a
+// move away from zero exactly when RelativeDeviation reaches its threshold.
+func TestRelativeThreshold_ZeroCurrent(t *testing.T) {
+	for _, test := range []struct {
+		threshold string
+		new       string
+		wantFired bool
+	}{
+		{"0.01", "5", true},
+		{"0.01", "-5", true},
+		{"1", "5", true},
+		{"1", "-5", true},
+		{"1.01", "5", false},
+		{"1.01", "-5", false},
+		{"0.01", "0", false},
+	} {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(`{"relativeThreshold": `+test.threshold+`}`))
+		new := decimal.RequireFromString(test.new)
+		fired, err := tfns[0].Triggering(context.Background(), decimal.Zero, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, test.wantFired, fired, "threshold %s, new %s", test.threshold, test.new)
+		deviation := triggerfns.RelativeDeviation(decimal.Zero, new)
+		assert.Equal(t, !deviation.LessThan(decimal.RequireFromString(test.threshold)) && !new.IsZero(), fired)
+	}
+}
+
+func TestAbsoluteDeviation(t *testing.T) {
+	for _, test := range []struct{ current, new, expected string }{
+		{"100", "100", "0"},
+		{"100", "102.5", "2.5"},
+		{"100", "97.5", "2.5"},
+		{"0", "-3", "3"},
+	} {
+		actual := triggerfns.AbsoluteDeviation(decimal.RequireFromString(test.current), decimal.RequireFromString(test.new))
+		assert.True(t, decimal.RequireFromString(test.expected).Equal(actual), "%s to %s: got %s", test.current, test.new, actual)
+	}
+}
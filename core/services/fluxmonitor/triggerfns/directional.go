@@ -0,0 +1,39 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// increaseThresholdFactory returns a TriggerFn which fires when new exceeds
+// current by at least the given amount. Decreases never fire it.
+func increaseThresholdFactory(params interface{}) (TriggerFn, error) {
+	t, parameter, err := exactNonNegative("increaseThreshold", params)
+	if err != nil {
+		return nil, err
+	}
+	return thresholdTriggerFn{
+		factory:   "increaseThreshold",
+		parameter: parameter,
+		threshold: t,
+		triggering: func(current, new decimal.Decimal) bool {
+			return new.Sub(current).GreaterThanOrEqual(t)
+		},
+		measure: increaseMeasure,
+	}, nil
+}
+
+// decreaseThresholdFactory is the mirror image of increaseThresholdFactory,
+// firing when new falls short of current by at least the given amount.
+func decreaseThresholdFactory(params interface{}) (TriggerFn, error) {
+	t, parameter, err := exactNonNegative("decreaseThreshold", params)
+	if err != nil {
+		return nil, err
+	}
+	return thresholdTriggerFn{
+		factory:   "decreaseThreshold",
+		parameter: parameter,
+		threshold: t,
+		triggering: func(current, new decimal.Decimal) bool {
+			return current.Sub(new).GreaterThanOrEqual(t)
+		},
+		measure: decreaseMeasure,
+	}, nil
+}
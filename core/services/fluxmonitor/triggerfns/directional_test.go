@@ -0,0 +1,60 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectionalThresholds(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "decreaseThreshold", "params": 2},
+		{"type": "increaseThreshold", "params": 2}
+	]`))
+	decrease, increase := tfns[0], tfns[1]
+	assert.Equal(t, float64(2), increase.Parameters())
+	assert.Equal(t, float64(2), decrease.Parameters())
+
+	tests := []struct {
+		name                       string
+		current, new               int64
+		wantIncrease, wantDecrease bool
+	}{
+		{"unchanged", 100, 100, false, false},
+		{"small rise", 100, 101, false, false},
+		{"rise at threshold", 100, 102, true, false},
+		{"large rise", 100, 110, true, false},
+		{"small fall", 100, 99, false, false},
+		{"fall at threshold", 100, 98, false, true},
+		{"large fall", 100, 90, false, true},
+		{"rise through zero", -1, 1, true, false},
+		{"fall through zero", 1, -1, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.NewFromInt(test.current), decimal.NewFromInt(test.new)
+			fired, err := increase.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantIncrease, fired, "increaseThreshold")
+			fired, err = decrease.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantDecrease, fired, "decreaseThreshold")
+		})
+	}
+}
+
+func TestDirectionalThresholds_BadParams(t *testing.T) {
+	for _, factory := range []string{"increaseThreshold", "decreaseThreshold"} {
+		_, err := triggerfns.ExportedMakeTriggerFn(factory, -1.0)
+		assert.Error(t, err, factory)
+		_, err = triggerfns.ExportedMakeTriggerFn(factory, "1")
+		assert.Error(t, err, factory)
+	}
+}
@@ -0,0 +1,45 @@
+package triggerfns
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownTriggerFn is returned, wrapped, when a spec names a trigger
+// function which isn't registered. Test for it with errors.Is.
+var ErrUnknownTriggerFn = errors.New("unknown trigger function")
+
+// ErrNotFinite is returned, wrapped, for a NaN or infinite answer or param,
+// which can't be compared meaningfully. Test for it with errors.Is.
+var ErrNotFinite = errors.New("not a finite number")
+
+// ErrConflictingTriggerFns is returned, wrapped, for a set which combines
+// trigger functions that contradict each other. Test for it with errors.Is.
+var ErrConflictingTriggerFns = errors.New("conflicting trigger functions")
+
+// ParamError is returned, wrapped, when a factory rejects the params it was
+// given. Extract it with errors.As.
+type ParamError struct {
+	// Factory is the name of the trigger function the params were for.
+	Factory string
+	// Value is the params as the factory received them.
+	Value interface{}
+	// Field is the parameter at fault, or "" if the problem is with the
+	// params as a whole.
+	Field string
+	// Err is the error the factory returned.
+	Err error
+}
+
+func (e ParamError) Error() string { return e.Err.Error() }
+func (e ParamError) Unwrap() error { return e.Err }
+
+// asParamError returns err from factory name as a ParamError, unless it
+// already contains one or an ErrUnknownTriggerFn, as errors from composites'
+// inner functions do.
+func asParamError(name string, params interface{}, err error) error {
+	var paramErr ParamError
+	if errors.As(err, &paramErr) || errors.Is(err, ErrUnknownTriggerFn) {
+		return err
+	}
+	return ParamError{Factory: name, Value: params, Err: err}
+}
@@ -0,0 +1,107 @@
+package triggerfns_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamError(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	err := tfns.Scan(`{"relativeThreshold": -0.01}`)
+	require.Error(t, err)
+
+	var paramErr triggerfns.ParamError
+	require.True(t, errors.As(err, &paramErr), err.Error())
+	assert.Equal(t, "relativeThreshold", paramErr.Factory)
+	assert.Equal(t, json.Number("-0.01"), paramErr.Value)
+	assert.Contains(t, err.Error(), paramErr.Error())
+	assert.False(t, errors.Is(err, triggerfns.ErrUnknownTriggerFn))
+}
+
+func TestParamError_InnerFunction(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	err := tfns.Scan(`{"and": {"staleness": 60, "absoluteThreshold": "lots"}}`)
+	require.Error(t, err)
+
+	var paramErr triggerfns.ParamError
+	require.True(t, errors.As(err, &paramErr), err.Error())
+	assert.Equal(t, "absoluteThreshold", paramErr.Factory)
+	assert.Equal(t, "lots", paramErr.Value)
+}
+
+func TestParamError_RegisteredFunction(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+
+	var tfns triggerfns.TriggerFns
+	err := tfns.Scan(`{"squareDeviation": "four"}`)
+
+	var paramErr triggerfns.ParamError
+	require.True(t, errors.As(err, &paramErr))
+	assert.Equal(t, "squareDeviation", paramErr.Factory)
+	assert.Equal(t, "four", paramErr.Value)
+}
+
+func TestErrUnknownTriggerFn(t *testing.T) {
+	for _, spec := range []string{
+		`{"frobnicate": 1}`,
+		`{"or": {"staleness": 60, "frobnicate": 1}}`,
+		`[{"type": "relativeThreshold", "params": -1}, {"type": "frobnicate", "params": 1}]`,
+	} {
+		var tfns triggerfns.TriggerFns
+		err := tfns.Scan(spec)
+		assert.True(t, errors.Is(err, triggerfns.ErrUnknownTriggerFn), spec)
+		assert.Contains(t, err.Error(), "unknown trigger function frobnicate", spec)
+	}
+
+	var paramErr triggerfns.ParamError
+	err := (&triggerfns.TriggerFns{}).Scan(`{"not": {"type": "frobnicate", "params": 1}}`)
+	assert.False(t, errors.As(err, &paramErr), "an unknown inner function is not a param error")
+
+	_, err = triggerfns.TriggerFnSchema("frobnicate")
+	assert.True(t, errors.Is(err, triggerfns.ErrUnknownTriggerFn))
+}
+
+func TestParamError_Field(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		factory   string
+		field     string
+		wantError string
+	}{
+		{"missing field", `{"relativeWithFloor": {"relative": 0.01}}`,
+			"relativeWithFloor", "absolute", "relativeWithFloor requires a absolute parameter"},
+		{"wrong type", `{"relativeThreshold": {"threshold": "1%"}}`,
+			"relativeThreshold", "threshold", "relativeThreshold requires threshold to be a number, got string"},
+		{"wrong type in object", `{"absoluteThreshold": {"threshold": true}}`,
+			"absoluteThreshold", "threshold", "absoluteThreshold requires threshold to be a number, got bool"},
+		{"unknown field", `{"relativeThreshold": {"threshold": 0.01, "thresold": 0.02}}`,
+			"relativeThreshold", "thresold", `relativeThreshold has malformed params: json: unknown field "thresold"`},
+		{"negative threshold", `{"relativeThreshold": -0.01}`,
+			"relativeThreshold", "threshold", "relativeThreshold requires a finite, non-negative parameter, got -0.01"},
+		{"malformed decimal string", `{"absoluteThreshold": "lots"}`,
+			"absoluteThreshold", "threshold", `absoluteThreshold requires a decimal string, got "lots"`},
+		{"inner function", `{"not": {"type": "band", "params": {"upPct": 1, "downPct": "1"}}}`,
+			"band", "downPct", "band requires downPct to be a number, got string"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			err := tfns.Scan(test.spec)
+			require.Error(t, err)
+
+			var paramErr triggerfns.ParamError
+			require.True(t, errors.As(err, &paramErr), err.Error())
+			assert.Equal(t, test.factory, paramErr.Factory)
+			assert.Equal(t, test.field, paramErr.Field)
+			assert.Contains(t, paramErr.Error(), test.wantError)
+		})
+	}
+}
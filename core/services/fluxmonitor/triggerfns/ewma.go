@@ -0,0 +1,74 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ewmaParams are the params of ewmaThreshold, e.g.
+// {"alpha": 0.2, "threshold": 0.005}.
+type ewmaParams struct {
+	Alpha     float64 `json:"alpha"`
+	Threshold float64 `json:"threshold"`
+}
+
+// ewmaTriggerFn fires when new differs from an exponentially weighted moving
+// average of the answers before it by at least threshold, as a fraction of
+// that average. Every answer it is shown is folded into the average, fired on
+// or not. The first answer only seeds the average, and never fires.
+type ewmaTriggerFn struct {
+	params    ewmaParams
+	threshold decimal.Decimal
+
+	mu     sync.Mutex
+	seeded bool
+	ewma   float64
+}
+
+func ewmaThresholdFactory(params interface{}) (TriggerFn, error) {
+	var p ewmaParams
+	if err := decodeParams("ewmaThreshold", params, &p); err != nil {
+		return nil, err
+	}
+	if !(p.Alpha > 0 && p.Alpha <= 1) {
+		return nil, errors.Errorf("ewmaThreshold requires an alpha in (0, 1], got %v", p.Alpha)
+	}
+	if _, err := nonNegativeFloat("ewmaThreshold", p.Threshold); err != nil {
+		return nil, err
+	}
+	return &ewmaTriggerFn{params: p, threshold: decimal.NewFromFloat(p.Threshold)}, nil
+}
+
+func (e *ewmaTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	answer, _ := new.Float64()
+	if math.IsNaN(answer) || math.IsInf(answer, 0) {
+		return false, fmt.Errorf("ewmaThreshold can't average %s: %w", new, ErrNotFinite)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.seeded {
+		e.seeded, e.ewma = true, answer
+		return false, nil
+	}
+	ewma, err := DecimalFromFloat(e.ewma)
+	if err != nil {
+		return false, errors.Wrap(err, "ewmaThreshold's average")
+	}
+	fired := relativeDeviationAtLeast(ewma, new, e.threshold)
+	e.ewma = e.params.Alpha*answer + (1-e.params.Alpha)*e.ewma
+	return fired, nil
+}
+
+// Clone returns an ewmaThreshold function with e's params and no average.
+func (e *ewmaTriggerFn) Clone() TriggerFn {
+	return &ewmaTriggerFn{params: e.params, threshold: e.threshold}
+}
+
+func (e *ewmaTriggerFn) stateful() bool          { return true }
+func (e *ewmaTriggerFn) Factory() string         { return "ewmaThreshold" }
+func (e *ewmaTriggerFn) Parameters() interface{} { return e.params }
@@ -0,0 +1,75 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEWMAThreshold_RampThenSpike(t *testing.T) {
+	tfn := mustScanOne(t, `{"ewmaThreshold": {"alpha": 0.5, "threshold": 0.01}}`)
+
+	assert.Equal(t, []bool{false}, feed(t, tfn, []float64{100}), "seed")
+
+	// The average lags a steady ramp by less than the threshold.
+	ramp := []float64{100.2, 100.4, 100.6, 100.8, 101}
+	assert.Equal(t, make([]bool, len(ramp)), feed(t, tfn, ramp), "ramp")
+
+	// A spike stands out from the average, and drags it up in turn, so that
+	// returning to the ramp stays within the threshold.
+	assert.Equal(t, []bool{true, false, false}, feed(t, tfn, []float64{103, 101.2, 101.4}), "spike")
+}
+
+func TestEWMAThreshold_UpdatesAverageWhenFiring(t *testing.T) {
+	tfn := mustScanOne(t, `{"ewmaThreshold": {"alpha": 0.5, "threshold": 0.01}}`)
+
+	// Averages after each answer: 100, 101.5, 102.25, 102.625.
+	expected := []bool{false, true, true, false}
+	assert.Equal(t, expected, feed(t, tfn, []float64{100, 103, 103, 103}))
+}
+
+func TestEWMAThreshold_CloneStartsUnseeded(t *testing.T) {
+	tfns := triggerfns.TriggerFns{mustScanOne(t, `{"ewmaThreshold": {"alpha": 0.2, "threshold": 0.005}}`)}
+	feed(t, tfns[0], []float64{100, 100})
+
+	clone := tfns.Clone()
+	assert.Equal(t, []bool{false}, feed(t, clone[0], []float64{200}), "first answer only seeds the clone")
+}
+
+func TestEWMAThreshold_Params(t *testing.T) {
+	tfn := mustScanOne(t, `{"ewmaThreshold": {"alpha": 0.2, "threshold": 0.005}}`)
+	params, err := json.Marshal(tfn.Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alpha": 0.2, "threshold": 0.005}`, string(params))
+
+	for _, bad := range []string{
+		`{"ewmaThreshold": 0.2}`,
+		`{"ewmaThreshold": {"alpha": 0, "threshold": 0.005}}`,
+		`{"ewmaThreshold": {"alpha": 1.5, "threshold": 0.005}}`,
+		`{"ewmaThreshold": {"alpha": 0.2, "threshold": -1}}`,
+		`{"ewmaThreshold": {"alpha": 0.2}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(bad), bad)
+	}
+}
+
+func TestEWMAThreshold_RejectsAnswersTooLargeForAFloat(t *testing.T) {
+	tfn := mustScanOne(t, `{"ewmaThreshold": {"alpha": 0.5, "threshold": 0.01}}`)
+	feed(t, tfn, []float64{100})
+
+	huge := decimal.New(1, 400)
+	_, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), huge, triggerfns.TriggerContext{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, triggerfns.ErrNotFinite))
+
+	// The answer wasn't folded into the average.
+	assert.Equal(t, []bool{false, true}, feed(t, tfn, []float64{100.5, 102}))
+}
@@ -0,0 +1,33 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// DecimalFromFloat returns x as a decimal, or an error wrapping ErrNotFinite
+// if x is NaN or infinite, on which decimal.NewFromFloat would panic.
+func DecimalFromFloat(x float64) (decimal.Decimal, error) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return decimal.Decimal{}, fmt.Errorf("%w: got %v", ErrNotFinite, x)
+	}
+	return decimal.NewFromFloat(x), nil
+}
+
+// ShouldReportFloat is ShouldReport for answers held as floats, e.g. as parsed
+// by an adapter. It returns an error wrapping ErrNotFinite, and doesn't
+// evaluate f, if either answer is NaN or infinite.
+func (f TriggerFns) ShouldReportFloat(ctx context.Context, current, new float64, tc TriggerContext) (bool, error) {
+	c, err := DecimalFromFloat(current)
+	if err != nil {
+		return false, fmt.Errorf("current answer is invalid: %w", err)
+	}
+	n, err := DecimalFromFloat(new)
+	if err != nil {
+		return false, fmt.Errorf("new answer is invalid: %w", err)
+	}
+	return f.ShouldReport(ctx, c, n, tc)
+}
@@ -0,0 +1,70 @@
+package triggerfns_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldReportFloat(t *testing.T) {
+	tfns := mustScan(t, `{"relativeThreshold": 0.01, "absoluteThreshold": 5}`)
+	ctx := context.Background()
+
+	fired, err := tfns.ShouldReportFloat(ctx, 100, 102, triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+
+	for _, test := range []struct {
+		name         string
+		current, new float64
+		wantErr      string
+	}{
+		{"NaN new", 100, math.NaN(), "new answer is invalid: not a finite number: got NaN"},
+		{"NaN current", math.NaN(), 100, "current answer is invalid: not a finite number: got NaN"},
+		{"infinite new", 100, math.Inf(1), "new answer is invalid: not a finite number: got +Inf"},
+		{"negative infinite current", math.Inf(-1), 100, "current answer is invalid: not a finite number: got -Inf"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfns.ShouldReportFloat(ctx, test.current, test.new, triggerfns.TriggerContext{})
+			assert.False(t, fired)
+			assert.EqualError(t, err, test.wantErr)
+			assert.True(t, errors.Is(err, triggerfns.ErrNotFinite))
+		})
+	}
+}
+
+func TestDecimalFromFloat(t *testing.T) {
+	d, err := triggerfns.DecimalFromFloat(1.25)
+	require.NoError(t, err)
+	assert.Equal(t, "1.25", d.String())
+
+	_, err = triggerfns.DecimalFromFloat(math.NaN())
+	assert.True(t, errors.Is(err, triggerfns.ErrNotFinite))
+}
+
+func TestNotFiniteParams(t *testing.T) {
+	for _, param := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := triggerfns.ExportedRelativeThresholdFactory(param)
+		assert.Error(t, err, "%v", param)
+
+		// Bare numbers which skip JSON are checked before any factory's own
+		// validation.
+		var p struct {
+			Threshold float64 `json:"threshold"`
+		}
+		err = triggerfns.ExportedDecodeParams("ewmaThreshold", param, &p)
+		assert.True(t, errors.Is(err, triggerfns.ErrNotFinite), "%v: %v", param, err)
+		var paramErr triggerfns.ParamError
+		require.True(t, errors.As(err, &paramErr))
+		assert.Equal(t, "threshold", paramErr.Field)
+
+		_, err = triggerfns.NewTriggerFns(map[string]interface{}{"relativeThreshold": param})
+		assert.True(t, errors.Is(err, triggerfns.ErrNotFinite), "%v: %v", param, err)
+	}
+}
@@ -0,0 +1,126 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["gapAware"] = FactoryFunc(gapAwareFactory)
+}
+
+// gapAwareParams are the params of gapAware, e.g.
+// {"maxGap": "10m", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type gapAwareParams struct {
+	MaxGap    duration      `json:"maxGap"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// gapAwareTriggerFn guards its inner function against the jump a feed can
+// show when it resumes after a gap in its samples. The first answer after a
+// gap longer than maxGap doesn't fire, but becomes the baseline the inner
+// function measures later answers against, in place of current, until the
+// inner function fires. The inner function is reset at the gap, as what it
+// saw before is stale.
+//
+// The gap is measured from tc.LastSampleAt, or, where that isn't known, from
+// the previous evaluation of the function.
+type gapAwareTriggerFn struct {
+	maxGap time.Duration
+	inner  TriggerFn
+	clock  utils.AfterNower
+
+	mu         sync.Mutex
+	lastSample time.Time
+	baseline   *decimal.Decimal
+}
+
+func gapAwareFactory(params interface{}) (TriggerFn, error) {
+	var p gapAwareParams
+	if err := decodeParams("gapAware", params, &p); err != nil {
+		return nil, err
+	}
+	if p.MaxGap == 0 {
+		return nil, errors.New("gapAware requires a positive maxGap")
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing gapAware's inner trigger function")
+	}
+	return &gapAwareTriggerFn{maxGap: time.Duration(p.MaxGap), inner: inner, clock: utils.Clock{}}, nil
+}
+
+func (g *gapAwareTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	g.mu.Lock()
+	now := g.clock.Now()
+	last := tc.LastSampleAt
+	if last.IsZero() {
+		last = g.lastSample
+	}
+	g.lastSample = now
+	if !last.IsZero() && now.Sub(last) > g.maxGap {
+		g.baseline = &new
+		g.mu.Unlock()
+		TriggerFns{g.inner}.ResetAll()
+		return false, nil
+	}
+	if g.baseline != nil {
+		current = *g.baseline
+	}
+	g.mu.Unlock()
+
+	fired, err := g.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	if fired {
+		g.mu.Lock()
+		g.baseline = nil
+		g.mu.Unlock()
+	}
+	return fired, nil
+}
+
+// SetClock sets the clock g measures gaps with, and passes it on to the inner
+// function.
+func (g *gapAwareTriggerFn) SetClock(clock utils.AfterNower) {
+	g.mu.Lock()
+	g.clock = clock
+	g.mu.Unlock()
+	TriggerFns{g.inner}.SetClock(clock)
+}
+
+// Clone returns a gapAware which has seen no samples, wrapping a clone of g's
+// inner function.
+func (g *gapAwareTriggerFn) Clone() TriggerFn {
+	return &gapAwareTriggerFn{maxGap: g.maxGap, inner: cloneTriggerFn(g.inner), clock: g.clock}
+}
+
+// Reset forgets g's last sample and baseline, and resets its inner function.
+func (g *gapAwareTriggerFn) Reset() {
+	g.mu.Lock()
+	g.lastSample, g.baseline = time.Time{}, nil
+	g.mu.Unlock()
+	TriggerFns{g.inner}.ResetAll()
+}
+
+func (g *gapAwareTriggerFn) Parameters() interface{} {
+	return gapAwareParams{
+		MaxGap:    duration(g.maxGap),
+		TriggerFn: triggerFnJSON{Type: g.inner.Factory(), Params: g.inner.Parameters()},
+	}
+}
+
+func (g *gapAwareTriggerFn) stateful() bool      { return true }
+func (g *gapAwareTriggerFn) Factory() string     { return "gapAware" }
+func (g *gapAwareTriggerFn) ParamSchema() string { return triggerFnSchemas["gapAware"] }
+func (g *gapAwareTriggerFn) String() string {
+	return fmt.Sprintf("gapAware(%s, %s)", g.maxGap, triggerFnString(g.inner))
+}
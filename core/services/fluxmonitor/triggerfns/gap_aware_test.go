@@ -0,0 +1,130 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gapAwareSpec = `{"gapAware": {
+	"maxGap": "5m",
+	"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+}}`
+
+// gapSample is an answer polled at an offset from the start of a test.
+type gapSample struct {
+	offset time.Duration
+	answer float64
+}
+
+// pollSamples evaluates tfn on each sample in turn, at its offset, starting
+// from current and moving it to each answer on which tfn fires.
+func pollSamples(t *testing.T, tfn triggerfns.TriggerFn, current float64, samples []gapSample) []bool {
+	t.Helper()
+	start := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	triggerfns.TriggerFns{tfn}.SetClock(clock)
+	cur := decimal.NewFromFloat(current)
+	fired := make([]bool, len(samples))
+	for i, sample := range samples {
+		clock.On("Now").Return(start.Add(sample.offset)).Once()
+		new := decimal.NewFromFloat(sample.answer)
+		var err error
+		fired[i], err = tfn.Triggering(context.Background(), cur, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		if fired[i] {
+			cur = new
+		}
+	}
+	clock.AssertExpectations(t)
+	return fired
+}
+
+func TestGapAware_TreatsAnswerAfterGapAsBaseline(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(gapAwareSpec))
+
+	fired := pollSamples(t, tfns[0], 100, []gapSample{
+		{0, 100},
+		{time.Minute, 100.5},
+		{2 * time.Minute, 102},    // fires, as usual
+		{10 * time.Minute, 120},   // after a gap, so becomes the baseline
+		{11 * time.Minute, 120.5}, // within 1% of the baseline
+		{12 * time.Minute, 122},   // 1.7% from the baseline
+		{13 * time.Minute, 122.5}, // measured from current again
+		{18 * time.Minute, 130},   // a gap of exactly maxGap isn't one
+	})
+	assert.Equal(t, []bool{false, false, true, false, false, true, false, true}, fired)
+}
+
+func TestGapAware_UsesLastSampleAt(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(gapAwareSpec))
+	now := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(now)
+	tfns.SetClock(clock)
+
+	// Even the first evaluation sees the gap, from the context.
+	fired, err := tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(120),
+		triggerfns.TriggerContext{LastSampleAt: now.Add(-6 * time.Minute)})
+	require.NoError(t, err)
+	assert.False(t, fired)
+
+	fired, err = tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(121),
+		triggerfns.TriggerContext{LastSampleAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.False(t, fired, "121 is within 1% of the baseline of 120")
+
+	fired, err = tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(130),
+		triggerfns.TriggerContext{LastSampleAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestGapAware_CloneAndReset(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(gapAwareSpec))
+	assert.Equal(t, []bool{false, false}, pollSamples(t, tfns[0], 100, []gapSample{
+		{0, 100},
+		{10 * time.Minute, 120},
+	}))
+
+	// A clone has seen no samples, so has no baseline.
+	clone := tfns.Clone()
+	assert.Equal(t, []bool{true}, pollSamples(t, clone[0], 100, []gapSample{{11 * time.Minute, 120.5}}))
+
+	tfns.ResetAll()
+	assert.Equal(t, []bool{true}, pollSamples(t, tfns[0], 100, []gapSample{{11 * time.Minute, 120.5}}))
+}
+
+func TestGapAware_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(gapAwareSpec))
+	assert.Equal(t, "gapAware(5m0s, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "gapAware", "params": {
+		"maxGap": "5m0s", "triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}]}`, string(value.([]byte)))
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, tfns.Equals(scanned))
+
+	for _, spec := range []string{
+		`{"gapAware": {"maxGap": "0s", "triggerFn": {"type": "always"}}}`,
+		`{"gapAware": {"maxGap": "-1m", "triggerFn": {"type": "always"}}}`,
+		`{"gapAware": {"maxGap": "5m"}}`,
+		`{"gapAware": {"maxGap": "5m", "triggerFn": {"type": "nonexistent"}}}`,
+	} {
+		assert.Error(t, scanned.Scan(spec), spec)
+	}
+}
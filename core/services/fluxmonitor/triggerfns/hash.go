@@ -0,0 +1,19 @@
+package triggerfns
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns a fingerprint of f, the hex SHA-256 of its Value, e.g. for
+// keying a cache by trigger configuration. Like Value it is independent of
+// f's order, and it changes with any function's params. It returns the empty
+// string if f can't be serialized, which Scan never produces.
+func (f TriggerFns) Hash() string {
+	value, err := f.Value()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(value.([]byte))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,33 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	a := mustScan(t, `{"relativeThreshold": 0.005, "staleness": 3600, "absoluteThreshold": 5}`)
+	reordered := triggerfns.TriggerFns{a[2], a[0], a[1]}
+	rescanned := mustScan(t, `[
+		{"type": "staleness", "params": 3600},
+		{"type": "absoluteThreshold", "params": 5},
+		{"type": "relativeThreshold", "params": 0.005}
+	]`)
+
+	assert.Len(t, a.Hash(), 64)
+	assert.Equal(t, a.Hash(), reordered.Hash())
+	assert.Equal(t, a.Hash(), rescanned.Hash())
+
+	for _, different := range []string{
+		`{"relativeThreshold": 0.01, "staleness": 3600, "absoluteThreshold": 5}`,
+		`{"relativeThreshold": 0.005, "staleness": 3600}`,
+		`{"relativeThreshold": 0.005, "staleness": 3600, "absoluteThreshold": 5, "never": null}`,
+		`{"and": {"relativeThreshold": 0.005, "staleness": 3600, "absoluteThreshold": 5}}`,
+	} {
+		assert.NotEqual(t, a.Hash(), mustScan(t, different).Hash(), different)
+	}
+	assert.Equal(t, triggerfns.TriggerFns{}.Hash(), triggerfns.TriggerFns(nil).Hash())
+}
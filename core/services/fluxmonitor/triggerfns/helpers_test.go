@@ -0,0 +1,34 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+func ExportedRelativeThresholdFactory(params interface{}) (TriggerFn, error) {
+	return relativeThresholdFactory(params)
+}
+
+func ExportedAbsoluteThresholdFactory(params interface{}) (TriggerFn, error) {
+	return absoluteThresholdFactory(params)
+}
+
+func ExportedMakeTriggerFn(name string, params interface{}) (TriggerFn, error) {
+	return makeTriggerFn(name, params)
+}
+
+func ExportedUnregisterTriggerFn(name string) {
+	triggerFnFactoriesMu.Lock()
+	defer triggerFnFactoriesMu.Unlock()
+	delete(triggerFnFactories, name)
+}
+
+func ExportedDecodeParams(factory string, params interface{}, out interface{}) error {
+	return decodeParams(factory, params, out)
+}
+
+func ExportedEffectiveThreshold(tfn TriggerFn) float64 {
+	a := unwrapMetered(tfn).(*adaptiveTriggerFn)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.effectiveThreshold()
+}
+
+func ExportedMedian(xs []decimal.Decimal) decimal.Decimal { return median(xs) }
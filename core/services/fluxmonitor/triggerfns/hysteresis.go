@@ -0,0 +1,93 @@
+package triggerfns
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// hysteresisTriggerFn measures the relative deviation from the last value it
+// fired on, rather than from current. It fires when that deviation reaches
+// upper, then stays quiet until the answer has settled back within lower of
+// the reported value. This stops an answer which bounces straight back after
+// a report from causing a second report in the other direction.
+type hysteresisTriggerFn struct {
+	upper, lower float64
+	upperDec     decimal.Decimal
+	lowerDec     decimal.Decimal
+
+	mu           sync.Mutex
+	armed        bool
+	hasReference bool
+	reference    decimal.Decimal
+}
+
+// hysteresisParams are the params of hysteresis, both relative to the last
+// reported value.
+type hysteresisParams struct {
+	Upper float64 `json:"upper"`
+	Lower float64 `json:"lower"`
+}
+
+// hysteresisThresholdFactory expects params of the form
+// {"upper": 0.01, "lower": 0.005}.
+func hysteresisThresholdFactory(params interface{}) (TriggerFn, error) {
+	var p hysteresisParams
+	if err := decodeParams("hysteresis", params, &p); err != nil {
+		return nil, err
+	}
+	if p.Lower > p.Upper {
+		return nil, errors.Errorf("hysteresis lower bound %v exceeds upper bound %v", p.Lower, p.Upper)
+	}
+	return &hysteresisTriggerFn{
+		upper:    p.Upper,
+		lower:    p.Lower,
+		upperDec: decimal.NewFromFloat(p.Upper),
+		lowerDec: decimal.NewFromFloat(p.Lower),
+		armed:    true,
+	}, nil
+}
+
+// Triggering takes current as the last reported value the first time it is
+// called, and afterwards tracks that value itself.
+func (h *hysteresisTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.hasReference {
+		h.reference = current
+		h.hasReference = true
+	}
+	if h.armed {
+		if relativeDeviationAtLeast(h.reference, new, h.upperDec) {
+			h.armed = false
+			h.reference = new
+			return true, nil
+		}
+		return false, nil
+	}
+	if !relativeDeviationAtLeast(h.reference, new, h.lowerDec) {
+		h.armed = true
+	}
+	return false, nil
+}
+
+// Clone returns a hysteresis function with h's bounds, armed and waiting to
+// take its reference value from its first evaluation.
+func (h *hysteresisTriggerFn) Clone() TriggerFn {
+	return &hysteresisTriggerFn{
+		upper:    h.upper,
+		lower:    h.lower,
+		upperDec: h.upperDec,
+		lowerDec: h.lowerDec,
+		armed:    true,
+	}
+}
+
+func (h *hysteresisTriggerFn) stateful() bool  { return true }
+func (h *hysteresisTriggerFn) Factory() string { return "hysteresis" }
+
+func (h *hysteresisTriggerFn) Parameters() interface{} {
+	return map[string]interface{}{"upper": h.upper, "lower": h.lower}
+}
@@ -0,0 +1,102 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustScanOne(t *testing.T, spec string) triggerfns.TriggerFn {
+	t.Helper()
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(spec))
+	require.Len(t, tfns, 1)
+	return tfns[0]
+}
+
+// pollSequence feeds answers to tfn the way the flux monitor does, moving
+// current to the new answer whenever a report is made.
+func pollSequence(t *testing.T, tfn triggerfns.TriggerFn, current float64, answers []float64) []bool {
+	t.Helper()
+	cur := decimal.NewFromFloat(current)
+	fired := make([]bool, len(answers))
+	for i, answer := range answers {
+		new := decimal.NewFromFloat(answer)
+		var err error
+		fired[i], err = tfn.Triggering(context.Background(), cur, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		if fired[i] {
+			cur = new
+		}
+	}
+	return fired
+}
+
+func TestHysteresis_FiresOnlyOnTransitions(t *testing.T) {
+	hysteresis := mustScanOne(t, `{"hysteresis": {"upper": 0.02, "lower": 0.01}}`)
+
+	answers := []float64{
+		101,   // 1% from 100, below upper
+		102,   // 2%, reaches upper and is reported
+		99.9,  // bounces 2.06% back from 102, suppressed
+		102,   // settles within lower of 102, re-arms
+		99.9,  // 2.06% from 102 again, now reported
+		102.1, // bounces 2.2% back from 99.9, suppressed
+	}
+	expected := []bool{false, true, false, false, true, false}
+	assert.Equal(t, expected, pollSequence(t, hysteresis, 100, answers))
+}
+
+func TestHysteresis_SuppressesFlapping(t *testing.T) {
+	answers := []float64{}
+	for i := 0; i < 5; i++ {
+		answers = append(answers, 102.1, 100)
+	}
+
+	relative := mustScanOne(t, `{"relativeThreshold": 0.02}`)
+	for i, fired := range pollSequence(t, relative, 100, answers) {
+		assert.True(t, fired, "relativeThreshold should report every swing, step %d", i)
+	}
+
+	// Each report is followed by a bounce back which is suppressed, and the
+	// return to the reported value which re-arms the trigger.
+	hysteresis := mustScanOne(t, `{"hysteresis": {"upper": 0.02, "lower": 0.01}}`)
+	expected := []bool{true, false, false, true, false, false, true, false, false, true}
+	assert.Equal(t, expected, pollSequence(t, hysteresis, 100, answers))
+}
+
+func TestHysteresis_ValueScanRoundTrip(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`{"hysteresis": {"upper": 0.02, "lower": 0.01}}`))
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "hysteresis", scanned[0].Factory())
+	assert.Equal(t, map[string]interface{}{"upper": 0.02, "lower": 0.01}, scanned[0].Parameters())
+}
+
+func TestHysteresis_BadParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"not an object", `{"hysteresis": 0.02}`},
+		{"missing lower", `{"hysteresis": {"upper": 0.02}}`},
+		{"lower above upper", `{"hysteresis": {"upper": 0.01, "lower": 0.02}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.params))
+		})
+	}
+}
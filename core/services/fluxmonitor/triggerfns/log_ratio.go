@@ -0,0 +1,49 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// logRatioTriggerFn fires when |ln(new/current)| is at least threshold, so
+// that a doubling and a halving count as equally large moves. Answers must be
+// positive: a ratio involving zero or a negative answer has no logarithm, and
+// Triggering returns an error for one rather than guessing.
+type logRatioTriggerFn struct {
+	threshold float64
+	// upper and lower are e^threshold and e^-threshold, the ratios of new to
+	// current at which it fires.
+	upper, lower decimal.Decimal
+}
+
+// logRatioFactory expects the threshold as a bare number or as
+// {"threshold": number}.
+func logRatioFactory(params interface{}) (TriggerFn, error) {
+	t, err := nonNegativeFloat("logRatio", params)
+	if err != nil {
+		return nil, err
+	}
+	upper := math.Exp(t)
+	if math.IsInf(upper, 1) {
+		return nil, errors.Errorf("logRatio threshold %v is too large", t)
+	}
+	return logRatioTriggerFn{
+		threshold: t,
+		upper:     decimal.NewFromFloat(upper),
+		lower:     decimal.NewFromFloat(math.Exp(-t)),
+	}, nil
+}
+
+func (l logRatioTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	if !current.IsPositive() || !new.IsPositive() {
+		return false, errors.Errorf("logRatio requires positive answers, got %s and %s", current, new)
+	}
+	return !new.LessThan(current.Mul(l.upper)) || !new.GreaterThan(current.Mul(l.lower)), nil
+}
+
+func (l logRatioTriggerFn) Factory() string         { return "logRatio" }
+func (l logRatioTriggerFn) Parameters() interface{} { return l.threshold }
+func (l logRatioTriggerFn) ParamSchema() string     { return triggerFnSchemas["logRatio"] }
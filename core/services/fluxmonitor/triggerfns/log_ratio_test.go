@@ -0,0 +1,62 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogRatio(t *testing.T) {
+	// ln(2) is about 0.6931, so a doubling or a halving fires
+	tfn := mustScanOne(t, `{"logRatio": 0.69}`)
+	assert.Equal(t, 0.69, tfn.Parameters())
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"doubling", "100", "200", true},
+		{"halving", "100", "50", true},
+		{"small rise", "100", "101", false},
+		{"small fall", "100", "99", false},
+		{"rise just short", "100", "199", false},
+		{"fall just short", "100", "50.3", false},
+		{"no change", "0.0001", "0.0001", false},
+		{"doubling of a tiny answer", "0.0001", "0.0002", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current),
+				decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestLogRatio_NonPositiveAnswers(t *testing.T) {
+	tfn := mustScanOne(t, `[{"type": "logRatio", "params": {"threshold": 0.1}}]`)
+	for _, answers := range [][2]int64{{0, 100}, {100, 0}, {-100, 100}, {100, -100}} {
+		_, err := tfn.Triggering(context.Background(), decimal.NewFromInt(answers[0]),
+			decimal.NewFromInt(answers[1]), triggerfns.TriggerContext{})
+		assert.Error(t, err, "%v", answers)
+	}
+}
+
+func TestLogRatio_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"logRatio": -0.1}`,
+		`{"logRatio": 1000}`,
+		`{"logRatio": "0.1"}`,
+		`{"logRatio": {"ratio": 2}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
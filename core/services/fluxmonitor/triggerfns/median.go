@@ -0,0 +1,87 @@
+package triggerfns
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// medianDeviationParams are the params of medianDeviation, e.g.
+// {"windowSize": 9, "threshold": 0.01}.
+type medianDeviationParams struct {
+	WindowSize int     `json:"windowSize"`
+	Threshold  float64 `json:"threshold"`
+}
+
+// medianDeviationTriggerFn fires when new differs from the median of the last
+// windowSize answers it was shown by at least threshold, as a fraction of
+// that median. Unlike a mean, the median isn't dragged along by a single
+// outlier. It records every answer, fired on or not, and never fires until
+// its window is full.
+type medianDeviationTriggerFn struct {
+	params    medianDeviationParams
+	threshold decimal.Decimal
+
+	mu     sync.Mutex
+	window []decimal.Decimal // ring buffer of the most recent answers
+	next   int               // index in window of the oldest answer, once it is full
+}
+
+func medianDeviationFactory(params interface{}) (TriggerFn, error) {
+	var p medianDeviationParams
+	if err := decodeParams("medianDeviation", params, &p); err != nil {
+		return nil, err
+	}
+	if p.WindowSize < 1 {
+		return nil, errors.Errorf("medianDeviation requires a windowSize of at least 1, got %d", p.WindowSize)
+	}
+	if _, err := nonNegativeFloat("medianDeviation", p.Threshold); err != nil {
+		return nil, err
+	}
+	return &medianDeviationTriggerFn{params: p, threshold: decimal.NewFromFloat(p.Threshold)}, nil
+}
+
+func (m *medianDeviationTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.window) < m.params.WindowSize {
+		m.window = append(m.window, new)
+		return false, nil
+	}
+	fired := relativeDeviationAtLeast(median(m.window), new, m.threshold)
+	m.window[m.next] = new
+	m.next = (m.next + 1) % len(m.window)
+	return fired, nil
+}
+
+// median returns the middle value of xs, or the mean of the two middle values
+// if there is an even number of them. It doesn't modify xs.
+func median(xs []decimal.Decimal) decimal.Decimal {
+	sorted := append([]decimal.Decimal(nil), xs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
+
+// Clone returns a medianDeviation function with m's params and an empty
+// window.
+func (m *medianDeviationTriggerFn) Clone() TriggerFn {
+	return &medianDeviationTriggerFn{params: m.params, threshold: m.threshold}
+}
+
+func (m *medianDeviationTriggerFn) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.window, m.next = nil, 0
+}
+
+func (m *medianDeviationTriggerFn) stateful() bool          { return true }
+func (m *medianDeviationTriggerFn) Factory() string         { return "medianDeviation" }
+func (m *medianDeviationTriggerFn) Parameters() interface{} { return m.params }
+func (m *medianDeviationTriggerFn) ParamSchema() string     { return triggerFnSchemas["medianDeviation"] }
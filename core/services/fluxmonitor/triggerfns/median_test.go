@@ -0,0 +1,84 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedianDeviation_IgnoresOutlier(t *testing.T) {
+	tfn := mustScanOne(t, `{"medianDeviation": {"windowSize": 5, "threshold": 0.05}}`)
+
+	// The 200 outlier pulls the window's mean up to 120, from which 104 is
+	// far off, but its median stays at 100.
+	warmUp := []float64{100, 101, 99, 100, 200}
+	assert.Equal(t, make([]bool, len(warmUp)), feed(t, tfn, warmUp), "warming up")
+	assert.Equal(t, []bool{false}, feed(t, tfn, []float64{104}), "near the median")
+
+	// The median is now that of 104, 101, 99, 100 and 200: 101.
+	assert.Equal(t, []bool{true}, feed(t, tfn, []float64{107}))
+}
+
+func TestMedianDeviation_DoesNotFireWhileWarmingUp(t *testing.T) {
+	tfn := mustScanOne(t, `{"medianDeviation": {"windowSize": 3, "threshold": 0.01}}`)
+	assert.Equal(t, []bool{false, false, false, true}, feed(t, tfn, []float64{100, 200, 300, 400}))
+}
+
+func TestMedianDeviation_EvenWindow(t *testing.T) {
+	tfn := mustScanOne(t, `{"medianDeviation": {"windowSize": 4, "threshold": 0.1}}`)
+	feed(t, tfn, []float64{10, 40, 20, 30})
+
+	// The median of 10, 20, 30 and 40 is 25, so the threshold is 2.5 away.
+	assert.Equal(t, []bool{false}, feed(t, tfn, []float64{27.4}))
+	tfn = mustScanOne(t, `{"medianDeviation": {"windowSize": 4, "threshold": 0.1}}`)
+	feed(t, tfn, []float64{10, 40, 20, 30})
+	assert.Equal(t, []bool{true}, feed(t, tfn, []float64{27.5}))
+}
+
+func TestMedianDeviation_ResetAndClone(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"medianDeviation": {"windowSize": 2, "threshold": 0.01}}`))
+	feed(t, tfns[0], []float64{100, 100})
+
+	clone := tfns.Clone()
+	tfns.ResetAll()
+	for _, tfn := range []triggerfns.TriggerFn{tfns[0], clone[0]} {
+		assert.Equal(t, []bool{false, false}, feed(t, tfn, []float64{200, 300}))
+	}
+}
+
+func TestMedianDeviation_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"medianDeviation": {"windowSize": 0, "threshold": 0.01}}`,
+		`{"medianDeviation": {"windowSize": 5, "threshold": -0.01}}`,
+		`{"medianDeviation": {"windowSize": 5}}`,
+		`{"medianDeviation": 0.01}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	for _, test := range []struct {
+		xs       []int64
+		expected string
+	}{
+		{[]int64{3}, "3"},
+		{[]int64{3, 1, 2}, "2"},
+		{[]int64{4, 1, 3, 2}, "2.5"},
+		{[]int64{-1, 1}, "0"},
+	} {
+		xs := make([]decimal.Decimal, len(test.xs))
+		for i, x := range test.xs {
+			xs[i] = decimal.NewFromInt(x)
+		}
+		actual := triggerfns.ExportedMedian(xs)
+		assert.True(t, decimal.RequireFromString(test.expected).Equal(actual), "%v: got %s", test.xs, actual)
+		assert.Equal(t, test.xs[0], xs[0].IntPart(), "input modified")
+	}
+}
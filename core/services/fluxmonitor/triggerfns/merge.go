@@ -0,0 +1,48 @@
+package triggerfns
+
+// Merge returns f with other's functions applied over it, as for a template
+// with per-feed overrides. Each function in other replaces every function in
+// f registered under the same name, whatever its params, and functions under
+// names f doesn't use are added. f's remaining functions keep their order,
+// followed by other's. Functions which Equals would consider the same are
+// only kept once. Neither f nor other is modified, and the result shares
+// their functions rather than cloning them.
+func (f TriggerFns) Merge(other TriggerFns) TriggerFns {
+	overridden := make(map[string]bool, len(other))
+	for _, tfn := range other {
+		overridden[tfn.Factory()] = true
+	}
+	merged := make(TriggerFns, 0, len(f)+len(other))
+	for _, tfn := range f {
+		if !overridden[tfn.Factory()] {
+			merged = appendUnique(merged, tfn)
+		}
+	}
+	for _, tfn := range other {
+		merged = appendUnique(merged, tfn)
+	}
+	return merged
+}
+
+// appendUnique appends tfn to fns unless fns already has an equal function.
+func appendUnique(fns TriggerFns, tfn TriggerFn) TriggerFns {
+	for _, existing := range fns {
+		if triggerFnEqual(existing, tfn) {
+			return fns
+		}
+	}
+	return append(fns, tfn)
+}
+
+// FilterByFactory returns those of f's functions registered under name, in
+// order, or nil if there are none. The functions are shared with f, not
+// cloned.
+func (f TriggerFns) FilterByFactory(name string) TriggerFns {
+	var filtered TriggerFns
+	for _, tfn := range f {
+		if tfn.Factory() == name {
+			filtered = append(filtered, tfn)
+		}
+	}
+	return filtered
+}
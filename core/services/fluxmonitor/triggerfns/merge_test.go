@@ -0,0 +1,74 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustScan(t *testing.T, spec string) triggerfns.TriggerFns {
+	t.Helper()
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(spec), spec)
+	return tfns
+}
+
+func TestMerge(t *testing.T) {
+	template := mustScan(t, `{"relativeThreshold": 0.005, "staleness": 3600}`)
+
+	tests := []struct {
+		name      string
+		overrides string
+		expected  string
+	}{
+		{"override a value", `{"relativeThreshold": 0.01}`, `{"relativeThreshold": 0.01, "staleness": 3600}`},
+		{"add a trigger", `{"absoluteThreshold": 5}`, `{"relativeThreshold": 0.005, "staleness": 3600, "absoluteThreshold": 5}`},
+		{"override and add", `{"staleness": 60, "absoluteThreshold": 5}`,
+			`{"relativeThreshold": 0.005, "staleness": 60, "absoluteThreshold": 5}`},
+		{"identical", `{"relativeThreshold": 0.005}`, `{"relativeThreshold": 0.005, "staleness": 3600}`},
+		{"no overrides", `[]`, `{"relativeThreshold": 0.005, "staleness": 3600}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged := template.Merge(mustScan(t, test.overrides))
+			expected := mustScan(t, test.expected)
+			assert.True(t, expected.Equals(merged), "expected %s, got %s", expected, merged)
+		})
+	}
+	assert.True(t, mustScan(t, `{"relativeThreshold": 0.005, "staleness": 3600}`).Equals(template), "template modified")
+}
+
+func TestMerge_Order(t *testing.T) {
+	merged := mustScan(t, `[{"type": "staleness", "params": 60}, {"type": "relativeThreshold", "params": 0.005}]`).
+		Merge(mustScan(t, `[{"type": "absoluteThreshold", "params": 5}, {"type": "staleness", "params": 30}]`))
+	require.Len(t, merged, 3)
+	factories := []string{merged[0].Factory(), merged[1].Factory(), merged[2].Factory()}
+	assert.Equal(t, []string{"relativeThreshold", "absoluteThreshold", "staleness"}, factories)
+}
+
+func TestMerge_Deduplicates(t *testing.T) {
+	var nilFns triggerfns.TriggerFns
+	merged := nilFns.Merge(mustScan(t, `[{"type": "absoluteThreshold", "params": 5}, {"type": "absoluteThreshold", "params": "5.0"}]`))
+	assert.Len(t, merged, 1)
+}
+
+func TestFilterByFactory(t *testing.T) {
+	mixed := mustScan(t, `[
+		{"type": "relativeThreshold", "params": 0.01},
+		{"type": "staleness", "params": 60},
+		{"type": "relativeThreshold", "params": {"threshold": 0.02, "fireOnFirst": false}},
+		{"type": "absoluteThreshold", "params": 5}
+	]`)
+
+	relative := mixed.FilterByFactory("relativeThreshold")
+	assert.Equal(t, "relativeThreshold(0.01), relativeThreshold(0.02, fireOnFirst: false)", relative.String())
+	staleness := mixed.FilterByFactory("staleness")
+	require.Len(t, staleness, 1)
+	assert.Equal(t, "staleness(60)", staleness.String())
+	assert.Len(t, mixed.FilterByFactory("absoluteThreshold"), 1)
+	assert.Nil(t, mixed.FilterByFactory("floor"))
+	assert.Len(t, mixed, 4)
+}
@@ -0,0 +1,252 @@
+package triggerfns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+)
+
+// triggerFnMetrics are the collectors WithMetrics reports to.
+type triggerFnMetrics struct {
+	evaluations *prometheus.CounterVec
+	deviation   *prometheus.HistogramVec
+	sinceFire   *prometheus.GaugeVec
+}
+
+// newTriggerFnMetrics registers the trigger function collectors with reg, or
+// returns those already registered there.
+func newTriggerFnMetrics(reg prometheus.Registerer) (*triggerFnMetrics, error) {
+	evaluations := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "flux_monitor_trigger_fn_evaluations_total",
+		Help: "The number of trigger function evaluations, by outcome (fired, suppressed or error), by factory and name",
+	},
+		[]string{"factory", "name", "outcome"},
+	)
+	deviation := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "flux_monitor_trigger_fn_deviation",
+		Help:    "The relative deviation of new answers from current answers seen by trigger functions",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 2, 16),
+	},
+		[]string{"factory", "name"},
+	)
+	sinceFire := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "flux_monitor_trigger_fn_seconds_since_fire",
+		Help: "The time since the trigger function last fired, as of its latest evaluation, by factory and name",
+	},
+		[]string{"factory", "name"},
+	)
+	if err := reg.Register(evaluations); err != nil {
+		existing, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			return nil, errors.Wrap(err, "while registering trigger function evaluations")
+		}
+		evaluations = existing.ExistingCollector.(*prometheus.CounterVec)
+	}
+	if err := reg.Register(deviation); err != nil {
+		existing, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			return nil, errors.Wrap(err, "while registering trigger function deviation")
+		}
+		deviation = existing.ExistingCollector.(*prometheus.HistogramVec)
+	}
+	if err := reg.Register(sinceFire); err != nil {
+		existing, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			return nil, errors.Wrap(err, "while registering trigger function time since fire")
+		}
+		sinceFire = existing.ExistingCollector.(*prometheus.GaugeVec)
+	}
+	return &triggerFnMetrics{evaluations: evaluations, deviation: deviation, sinceFire: sinceFire}, nil
+}
+
+// WithMetrics returns a copy of f whose functions report each evaluation to
+// reg, counting how often each factory fires, is suppressed or errors, and
+// recording the relative deviation it was shown and the time since it last
+// fired, or since its first evaluation if it hasn't. Each is labeled with the
+// function's factory and its name, which is empty for unnamed functions. That time is read from
+// the clock passed to SetClock, which should be called on the copy. f itself
+// is not metered. Calling WithMetrics for several jobs with the same reg
+// shares collectors, so the time since firing is that of whichever job's
+// function of a factory was evaluated last. Any AuditSink f's functions
+// report to is kept.
+func (f TriggerFns) WithMetrics(reg prometheus.Registerer) (TriggerFns, error) {
+	metrics, err := newTriggerFnMetrics(reg)
+	if err != nil {
+		return nil, err
+	}
+	metered := make(TriggerFns, len(f))
+	for i, tfn := range f {
+		m := asMetered(tfn)
+		m.metrics = metrics
+		m.fires = &lastFire{clock: utils.Clock{}}
+		metered[i] = m
+	}
+	return metered, nil
+}
+
+// meteredTriggerFn records the evaluations of the TriggerFn it wraps, to
+// metrics and audit where set, and passes on the optional interfaces that
+// TriggerFn implements. It also carries the name of a named function.
+type meteredTriggerFn struct {
+	TriggerFn
+	// name is the name the function was given, or "" if it has none.
+	name string
+	// params are the params the function was given, less its name, if it has
+	// one.
+	params  map[string]interface{}
+	metrics *triggerFnMetrics
+	audit   AuditSink
+	// dryRun is set by TriggerFns.DryRun, to record each outcome but never
+	// fire.
+	dryRun bool
+	// fires tracks when the function last fired, if metrics are set.
+	fires *lastFire
+}
+
+// lastFire tracks when a metered function last fired.
+type lastFire struct {
+	mu    sync.Mutex
+	clock utils.AfterNower
+	last  time.Time
+}
+
+// since returns the time since the last fire, or since the first call if
+// there hasn't been one, and then records a fire now if fired is set.
+func (l *lastFire) since(fired bool) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	if l.last.IsZero() || fired {
+		l.last = now
+	}
+	return now.Sub(l.last)
+}
+
+func (l *lastFire) setClock(clock utils.AfterNower) {
+	l.mu.Lock()
+	l.clock = clock
+	l.mu.Unlock()
+}
+
+// clone returns a lastFire on the same clock which has never fired.
+func (l *lastFire) clone() *lastFire {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &lastFire{clock: l.clock}
+}
+
+func (m meteredTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := m.TriggerFn.Triggering(ctx, current, new, tc)
+	m.record(current, new, fired, err)
+	return fired && !m.dryRun, err
+}
+
+func (m meteredTriggerFn) TriggeringWithReason(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (TriggerResult, error) {
+	result, err := TriggeringWithReason(ctx, m.TriggerFn, current, new, tc)
+	m.record(current, new, result.Fired, err)
+	if m.dryRun && result.Fired {
+		result.Fired = false
+		result.Reason = "dry run, would have fired: " + result.Reason
+	}
+	return result, err
+}
+
+// record reports an evaluation of current and new to m's collectors and
+// audit sink.
+func (m meteredTriggerFn) record(current, new decimal.Decimal, fired bool, err error) {
+	if m.audit != nil {
+		m.audit.Record(m.Factory(), current, new, fired, err)
+	}
+	if m.metrics == nil {
+		return
+	}
+	outcome := "suppressed"
+	if err != nil {
+		outcome = "error"
+	} else if fired {
+		outcome = "fired"
+	}
+	m.metrics.evaluations.WithLabelValues(m.Factory(), m.name, outcome).Inc()
+	deviation, _ := RelativeDeviation(current, new).Float64()
+	m.metrics.deviation.WithLabelValues(m.Factory(), m.name).Observe(deviation)
+	if m.fires != nil {
+		since := m.fires.since(fired && err == nil)
+		m.metrics.sinceFire.WithLabelValues(m.Factory(), m.name).Set(since.Seconds())
+	}
+}
+
+func (m meteredTriggerFn) Clone() TriggerFn {
+	m.TriggerFn = cloneTriggerFn(m.TriggerFn)
+	if m.fires != nil {
+		m.fires = m.fires.clone()
+	}
+	return m
+}
+
+func (m meteredTriggerFn) SetClock(clock utils.AfterNower) {
+	if m.fires != nil {
+		m.fires.setClock(clock)
+	}
+	if c, ok := m.TriggerFn.(Clocked); ok {
+		c.SetClock(clock)
+	}
+}
+
+func (m meteredTriggerFn) paramsWarning() string {
+	if w, ok := m.TriggerFn.(paramsWarner); ok {
+		return w.paramsWarning()
+	}
+	return ""
+}
+
+func (m meteredTriggerFn) ParamSchema() string {
+	if s, ok := m.TriggerFn.(SchemaProvider); ok {
+		return s.ParamSchema()
+	}
+	return ""
+}
+
+// Parameters returns the params of the wrapped function, with its name if it
+// has one, so that they build the same named function again.
+func (m meteredTriggerFn) Parameters() interface{} {
+	if m.name == "" {
+		return m.TriggerFn.Parameters()
+	}
+	params := map[string]interface{}{"name": m.name}
+	for key, value := range m.params {
+		params[key] = value
+	}
+	return params
+}
+
+func (m meteredTriggerFn) stateful() bool { return isStateful(m.TriggerFn) }
+
+func (m meteredTriggerFn) String() string {
+	if m.name != "" {
+		return m.name + ": " + triggerFnString(m.TriggerFn)
+	}
+	return triggerFnString(m.TriggerFn)
+}
+
+// unwrapMetered returns the function tfn meters, or tfn if it isn't metered.
+func unwrapMetered(tfn TriggerFn) TriggerFn {
+	if m, ok := tfn.(meteredTriggerFn); ok {
+		return m.TriggerFn
+	}
+	return tfn
+}
+
+// asMetered returns tfn as a meteredTriggerFn, wrapping it with no metrics
+// or audit sink if it isn't one already.
+func asMetered(tfn TriggerFn) meteredTriggerFn {
+	if m, ok := tfn.(meteredTriggerFn); ok {
+		return m
+	}
+	return meteredTriggerFn{TriggerFn: tfn}
+}
@@ -0,0 +1,173 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func evaluationCount(t *testing.T, reg *prometheus.Registry, factory, outcome string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "flux_monitor_trigger_fn_evaluations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["factory"] == factory && labels["outcome"] == outcome {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func deviationSamples(t *testing.T, reg *prometheus.Registry, factory string) uint64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "flux_monitor_trigger_fn_deviation" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() == factory {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestTriggerFns_WithMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "relativeThreshold", "params": 0.01},
+		{"type": "staleness", "params": 60}
+	]`))
+	metered, err := tfns.WithMetrics(reg)
+	require.NoError(t, err)
+	relative, staleness := metered[0], metered[1]
+
+	ctx := context.Background()
+	current := decimal.NewFromInt(100)
+	poll := func(tfn triggerfns.TriggerFn, new int64) {
+		_, _ = tfn.Triggering(ctx, current, decimal.NewFromInt(new), triggerfns.TriggerContext{})
+	}
+
+	poll(relative, 102)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "relativeThreshold", "fired"))
+	poll(relative, 100)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "relativeThreshold", "fired"))
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "relativeThreshold", "suppressed"))
+
+	// Without a last report time, staleness errors.
+	poll(staleness, 100)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "staleness", "error"))
+	assert.Equal(t, float64(0), evaluationCount(t, reg, "staleness", "fired"))
+
+	assert.Equal(t, uint64(2), deviationSamples(t, reg, "relativeThreshold"))
+
+	// The unmetered originals report nothing.
+	poll(tfns[0], 102)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "relativeThreshold", "fired"))
+}
+
+func TestTriggerFns_WithMetrics_SharesCollectors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	first, err := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.01)}.WithMetrics(reg)
+	require.NoError(t, err)
+	second, err := triggerfns.TriggerFns{mustAbsoluteThreshold(t, 1)}.WithMetrics(reg)
+	require.NoError(t, err)
+
+	ctx, tc := context.Background(), triggerfns.TriggerContext{}
+	_, err = first[0].Triggering(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), tc)
+	require.NoError(t, err)
+	_, err = second[0].Triggering(ctx, decimal.NewFromInt(100), decimal.NewFromInt(102), tc)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "relativeThreshold", "fired"))
+	assert.Equal(t, float64(1), evaluationCount(t, reg, "absoluteThreshold", "fired"))
+}
+
+func TestTriggerFns_WithMetrics_KeepsBehaviour(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "absoluteThreshold", "params": 0},
+		{"type": "hysteresis", "params": {"upper": 0.02, "lower": 0.01}}
+	]`))
+	metered, err := tfns.WithMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	assert.Equal(t, tfns.String(), metered.String())
+	assert.True(t, tfns.Equals(metered))
+	assert.True(t, triggerfns.IsWarning(metered.Validate()))
+
+	value, err := metered.Value()
+	require.NoError(t, err)
+	expected, err := tfns.Value()
+	require.NoError(t, err)
+	assert.Equal(t, expected, value)
+
+	// Metered clones don't share hysteresis state with the original.
+	clone := metered.Clone()
+	assert.Equal(t, []bool{true}, pollSequence(t, metered[1], 100, []float64{102}))
+	assert.Equal(t, []bool{true}, pollSequence(t, clone[1], 100, []float64{102}))
+}
+
+func secondsSinceFire(t *testing.T, reg *prometheus.Registry, factory string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "flux_monitor_trigger_fn_seconds_since_fire" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.GetLabel()[0].GetValue() == factory {
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return -1
+}
+
+func TestTriggerFns_WithMetrics_SecondsSinceFire(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metered, err := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.01)}.WithMetrics(reg)
+	require.NoError(t, err)
+	clock := new(mocks.AfterNower)
+	metered.SetClock(clock)
+
+	start := time.Unix(1000000, 0)
+	poll := func(offset time.Duration, new int64) float64 {
+		clock.On("Now").Return(start.Add(offset)).Once()
+		_, err := metered[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(new),
+			triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		return secondsSinceFire(t, reg, "relativeThreshold")
+	}
+
+	// Before the first fire, the time counts from the first evaluation.
+	assert.Equal(t, float64(0), poll(0, 100))
+	assert.Equal(t, float64(30), poll(30*time.Second, 100))
+	// Firing resets it, and it grows again from there.
+	assert.Equal(t, float64(0), poll(time.Minute, 102))
+	assert.Equal(t, float64(15), poll(time.Minute+15*time.Second, 100))
+	assert.Equal(t, float64(120), poll(3*time.Minute, 100))
+	assert.Equal(t, float64(0), poll(4*time.Minute, 98))
+	clock.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package triggerfns
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Any trigger function given object params may be named, e.g.
+// {"relativeThreshold": {"threshold": 0.01, "name": "fast"}}, so that several
+// functions of one factory can be told apart. The name shows in String, in
+// the "name" label of the metrics WithMetrics reports, and in place of the
+// factory name in the output of Evaluate. makeTriggerFn removes it from the
+// params before they reach the factory, so a factory can't take a param
+// called "name" of its own.
+
+// splitName removes the "name" key from params for factory, returning the name
+// and the params left, or "" and params unchanged if they aren't named.
+func splitName(factory string, params interface{}) (string, interface{}, error) {
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return "", params, nil
+	}
+	raw, ok := m["name"]
+	if !ok {
+		return "", params, nil
+	}
+	name, ok := raw.(string)
+	if !ok || name == "" {
+		return "", nil, ParamError{Factory: factory, Value: params, Field: "name",
+			Err: errors.Errorf("%s requires name to be a non-empty string, got %v", factory, raw)}
+	}
+	rest := make(map[string]interface{}, len(m)-1)
+	for key, value := range m {
+		if key != "name" {
+			rest[key] = value
+		}
+	}
+	return name, rest, nil
+}
+
+// triggerFnName returns the name tfn was given, or "" if it has none.
+func triggerFnName(tfn TriggerFn) string {
+	if m, ok := tfn.(meteredTriggerFn); ok {
+		return m.name
+	}
+	return ""
+}
+
+// triggerFnLabel returns the name tfn was given, or its factory name if it has
+// none.
+func triggerFnLabel(tfn TriggerFn) string {
+	if name := triggerFnName(tfn); name != "" {
+		return name
+	}
+	return tfn.Factory()
+}
@@ -0,0 +1,130 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const namedSpec = `[
+	{"type": "relativeThreshold", "params": {"threshold": 0.01, "name": "fast"}},
+	{"type": "relativeThreshold", "params": {"threshold": 0.05, "name": "slow"}},
+	{"type": "staleness", "params": 60}
+]`
+
+func TestNamedTriggerFns_String(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(namedSpec))
+	assert.Equal(t, "fast: relativeThreshold(0.01), slow: relativeThreshold(0.05), staleness(60)", tfns.String())
+}
+
+func TestNamedTriggerFns_RoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(namedSpec))
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [
+		{"type": "relativeThreshold", "params": {"name": "fast", "threshold": 0.01}},
+		{"type": "relativeThreshold", "params": {"name": "slow", "threshold": 0.05}},
+		{"type": "staleness", "params": 60}
+	]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, tfns.Equals(scanned))
+	assert.Equal(t, tfns.String(), scanned.String())
+	assert.NoError(t, scanned.Validate())
+
+	// Names are part of a function's identity.
+	var renamed triggerfns.TriggerFns
+	require.NoError(t, renamed.Scan(`[
+		{"type": "relativeThreshold", "params": {"threshold": 0.01, "name": "quick"}},
+		{"type": "relativeThreshold", "params": {"threshold": 0.05, "name": "slow"}},
+		{"type": "staleness", "params": 60}
+	]`))
+	assert.False(t, tfns.Equals(renamed))
+}
+
+func TestNamedTriggerFns_Evaluate(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(namedSpec))
+	fired, err := tfns.Evaluate(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102),
+		triggerfns.TriggerContext{LastReportedAt: time.Now()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fast"}, fired)
+}
+
+func TestNamedTriggerFns_InsideComposite(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"not": {"type": "always", "params": {"name": "off"}}}`))
+	assert.Equal(t, "not(off: always(null))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, tfns.String(), scanned.String())
+}
+
+func TestNamedTriggerFns_WithMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(namedSpec))
+	metered, err := tfns.WithMetrics(reg)
+	require.NoError(t, err)
+	assert.Equal(t, tfns.String(), metered.String())
+
+	for _, tfn := range metered {
+		_, _ = tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102),
+			triggerfns.TriggerContext{})
+	}
+	assert.Equal(t, map[string]float64{
+		"relativeThreshold/fast/fired":      1,
+		"relativeThreshold/slow/suppressed": 1,
+		"staleness//error":                  1,
+	}, evaluationsByName(t, reg))
+}
+
+// evaluationsByName returns the evaluation counts in reg, keyed by
+// "factory/name/outcome".
+func evaluationsByName(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	counts := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "flux_monitor_trigger_fn_evaluations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			counts[labels["factory"]+"/"+labels["name"]+"/"+labels["outcome"]] = metric.GetCounter().GetValue()
+		}
+	}
+	return counts
+}
+
+func TestNamedTriggerFns_InvalidName(t *testing.T) {
+	for _, spec := range []string{
+		`{"relativeThreshold": {"threshold": 0.01, "name": 7}}`,
+		`{"relativeThreshold": {"threshold": 0.01, "name": ""}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		err := tfns.Scan(spec)
+		require.Error(t, err, spec)
+		var paramErr triggerfns.ParamError
+		require.True(t, errors.As(err, &paramErr), spec)
+		assert.Equal(t, "name", paramErr.Field)
+	}
+}
@@ -0,0 +1,57 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// NextReportBounds returns the band of answers around current on which f
+// won't report, e.g. for showing which prices would next be reported. An
+// answer strictly between low and high doesn't report, but one reaching
+// either does. It handles sets of relativeThreshold, relativeBps and
+// absoluteThreshold functions, whose bands are intersected, since any of
+// them firing reports. For any other function, a relativeThreshold with
+// useOnchain, whose band is around the on-chain answer rather than current, a
+// zero current against a relative threshold or an empty f, which never
+// reports, ok is false.
+func NextReportBounds(f TriggerFns, current decimal.Decimal) (low, high decimal.Decimal, ok bool) {
+	if len(f) == 0 {
+		return decimal.Decimal{}, decimal.Decimal{}, false
+	}
+	for i, tfn := range f {
+		t, isThreshold := unwrapMetered(tfn).(thresholdTriggerFn)
+		if !isThreshold {
+			return decimal.Decimal{}, decimal.Decimal{}, false
+		}
+		l, h, bounded := t.bounds(current)
+		if !bounded {
+			return decimal.Decimal{}, decimal.Decimal{}, false
+		}
+		if i == 0 || l.GreaterThan(low) {
+			low = l
+		}
+		if i == 0 || h.LessThan(high) {
+			high = h
+		}
+	}
+	return low, high, true
+}
+
+// bounds returns the band of answers around current on which f doesn't
+// fire, for the factories whose band is symmetric and bounded.
+func (f thresholdTriggerFn) bounds(current decimal.Decimal) (low, high decimal.Decimal, ok bool) {
+	if f.useOnchain {
+		return decimal.Decimal{}, decimal.Decimal{}, false
+	}
+	var margin decimal.Decimal
+	switch f.factory {
+	case "relativeThreshold", "relativeBps":
+		// From zero, any move fires or, with fireOnFirst false, none does.
+		if current.IsZero() {
+			return decimal.Decimal{}, decimal.Decimal{}, false
+		}
+		margin = f.threshold.Mul(current.Abs())
+	case "absoluteThreshold":
+		margin = f.threshold
+	default:
+		return decimal.Decimal{}, decimal.Decimal{}, false
+	}
+	return current.Sub(margin), current.Add(margin), true
+}
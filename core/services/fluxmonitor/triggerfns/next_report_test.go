@@ -0,0 +1,77 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireBounds(t *testing.T, spec string, current, wantLow, wantHigh string) {
+	t.Helper()
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(spec))
+	low, high, ok := triggerfns.NextReportBounds(tfns, decimal.RequireFromString(current))
+	require.True(t, ok, spec)
+	assert.Equal(t, wantLow, low.String(), spec)
+	assert.Equal(t, wantHigh, high.String(), spec)
+
+	// The bounds themselves report, and answers just inside them don't.
+	c := decimal.RequireFromString(current)
+	tick := decimal.New(1, -12)
+	for _, test := range []struct {
+		new   decimal.Decimal
+		fires bool
+	}{
+		{low, true}, {high, true}, {low.Add(tick), false}, {high.Sub(tick), false},
+	} {
+		fired, err := tfns.ShouldReport(context.Background(), c, test.new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, test.fires, fired, "%s at %s", spec, test.new)
+	}
+}
+
+func TestNextReportBounds(t *testing.T) {
+	requireBounds(t, `{"relativeThreshold": 0.01}`, "200", "198", "202")
+	requireBounds(t, `{"relativeThreshold": 0.01}`, "-200", "-202", "-198")
+	requireBounds(t, `{"relativeBps": 50}`, "200", "199", "201")
+	requireBounds(t, `{"absoluteThreshold": 2.5}`, "200", "197.5", "202.5")
+	// Whichever function is closer sets each bound.
+	requireBounds(t, `{"relativeThreshold": 0.01, "absoluteThreshold": 1}`, "200", "199", "201")
+	requireBounds(t, `{"relativeThreshold": 0.01, "absoluteThreshold": 5}`, "200", "198", "202")
+}
+
+func TestNextReportBounds_Metered(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+	metered, err := tfns.WithMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	low, high, ok := triggerfns.NextReportBounds(metered, decimal.NewFromInt(100))
+	require.True(t, ok)
+	assert.Equal(t, "99", low.String())
+	assert.Equal(t, "101", high.String())
+}
+
+func TestNextReportBounds_NotBounded(t *testing.T) {
+	for _, test := range []struct {
+		spec, current string
+	}{
+		{`{}`, "100"},
+		{`{"relativeThreshold": 0.01}`, "0"},
+		{`{"relativeThreshold": 0.01, "staleness": 60}`, "100"},
+		{`{"relativeThreshold": {"threshold": 0.01, "useOnchain": true}}`, "100"},
+		{`{"and": {"relativeThreshold": 0.01, "absoluteThreshold": 1}}`, "100"},
+		{`{"increaseThreshold": 1}`, "100"},
+		{`{"zscore": {"windowSize": 5, "sigma": 2}}`, "100"},
+	} {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(test.spec))
+		_, _, ok := triggerfns.NextReportBounds(tfns, decimal.RequireFromString(test.current))
+		assert.False(t, ok, test.spec)
+	}
+}
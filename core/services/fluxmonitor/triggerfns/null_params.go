@@ -0,0 +1,21 @@
+package triggerfns
+
+import "sync/atomic"
+
+// skipNullParams is set to 1 when Scan should drop entries whose params are
+// null, rather than failing.
+var skipNullParams int32
+
+// SetSkipNullParams controls whether Scan skips, with a logged warning, an
+// entry whose factory rejects null params, such as {"staleness": null}
+// left by a partial migration. Otherwise Scan returns a ParamError naming the
+// function. Entries which accept null, like always, are unaffected.
+func SetSkipNullParams(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&skipNullParams, v)
+}
+
+func useSkipNullParams() bool { return atomic.LoadInt32(&skipNullParams) == 1 }
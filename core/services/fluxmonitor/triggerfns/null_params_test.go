@@ -0,0 +1,45 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nullParamsSpec = `{"staleness": null, "absoluteThreshold": 0.01, "always": null}`
+
+func TestTriggerFns_Scan_NullParams_Strict(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	err := tfns.Scan(nullParamsSpec)
+	require.Error(t, err)
+	assert.EqualError(t, err, "triggerFns.staleness: staleness has null params")
+
+	var paramErr triggerfns.ParamError
+	require.True(t, errors.As(err, &paramErr))
+	assert.Equal(t, "staleness", paramErr.Factory)
+	assert.Nil(t, paramErr.Value)
+
+	// Null params inside a composite are reported by the composite as usual.
+	err = tfns.Scan(`{"and": {"absoluteThreshold": null, "staleness": 60}}`)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "null params")
+}
+
+func TestTriggerFns_Scan_NullParams_Skip(t *testing.T) {
+	triggerfns.SetSkipNullParams(true)
+	defer triggerfns.SetSkipNullParams(false)
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(nullParamsSpec))
+	require.Len(t, tfns, 2)
+	assert.Equal(t, "absoluteThreshold", tfns[0].Factory())
+	assert.Equal(t, "always", tfns[1].Factory())
+
+	// Other problems are still errors.
+	assert.Error(t, tfns.Scan(`{"staleness": null, "frobnicate": null}`))
+	assert.Error(t, tfns.Scan(`{"staleness": null, "absoluteThreshold": -1}`))
+}
@@ -0,0 +1,63 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["onlyOnChange"] = FactoryFunc(onlyOnChangeFactory)
+}
+
+// onlyOnChangeTriggerFn fires when its inner function does, except when new
+// equals current, so that a zero threshold doesn't fire on an unchanged
+// answer. The inner function is evaluated either way, so that a stateful one
+// sees every answer.
+type onlyOnChangeTriggerFn struct {
+	inner TriggerFn
+}
+
+// onlyOnChangeFactory expects the spec of the inner function, e.g.
+// {"type": "relativeThreshold", "params": 0.01}.
+func onlyOnChangeFactory(params interface{}) (TriggerFn, error) {
+	var p triggerFnJSON
+	if err := decodeParams("onlyOnChange", params, &p); err != nil {
+		return nil, err
+	}
+	inner, err := makeTriggerFn(p.Type, p.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing onlyOnChange's inner trigger function")
+	}
+	return onlyOnChangeTriggerFn{inner: inner}, nil
+}
+
+func (o onlyOnChangeTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := o.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	return fired && !new.Equal(current), nil
+}
+
+func (o onlyOnChangeTriggerFn) Clone() TriggerFn {
+	return onlyOnChangeTriggerFn{inner: cloneTriggerFn(o.inner)}
+}
+
+func (o onlyOnChangeTriggerFn) Parameters() interface{} {
+	return triggerFnJSON{Type: o.inner.Factory(), Params: o.inner.Parameters()}
+}
+
+func (o onlyOnChangeTriggerFn) SetClock(clock utils.AfterNower) {
+	TriggerFns{o.inner}.SetClock(clock)
+}
+func (o onlyOnChangeTriggerFn) Reset()              { TriggerFns{o.inner}.ResetAll() }
+func (o onlyOnChangeTriggerFn) stateful() bool      { return isStateful(o.inner) }
+func (o onlyOnChangeTriggerFn) Factory() string     { return "onlyOnChange" }
+func (o onlyOnChangeTriggerFn) ParamSchema() string { return triggerFnSchemas["onlyOnChange"] }
+func (o onlyOnChangeTriggerFn) String() string {
+	return "onlyOnChange(" + triggerFnString(o.inner) + ")"
+}
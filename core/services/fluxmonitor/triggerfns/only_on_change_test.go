@@ -0,0 +1,62 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnlyOnChange(t *testing.T) {
+	inner := mustScanOne(t, `{"absoluteThreshold": 0}`)
+	tfn := mustScanOne(t, `{"onlyOnChange": {"type": "absoluteThreshold", "params": 0}}`)
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"unchanged", "100", "100", false},
+		{"unchanged, different exponent", "100", "100.000", false},
+		{"unchanged zero", "0", "0", false},
+		{"rise", "100", "100.01", true},
+		{"fall", "100", "99.99", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.RequireFromString(test.current), decimal.RequireFromString(test.new)
+			innerFired, err := inner.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.True(t, innerFired, "absoluteThreshold of 0 should fire on any answer")
+
+			fired, err := tfn.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestOnlyOnChange_Parameters(t *testing.T) {
+	tfn := mustScanOne(t, `{"onlyOnChange": {"type": "relativeThreshold", "params": 0.01}}`)
+	assert.Equal(t, "onlyOnChange", tfn.Factory())
+	assert.Equal(t, "onlyOnChange(relativeThreshold(0.01))", tfn.(interface{ String() string }).String())
+	params, err := json.Marshal(tfn.Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "relativeThreshold", "params": 0.01}`, string(params))
+}
+
+func TestOnlyOnChange_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"onlyOnChange": 0}`,
+		`{"onlyOnChange": {"type": "frobnicate", "params": 1}}`,
+		`{"onlyOnChange": {"type": "absoluteThreshold", "params": -1}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
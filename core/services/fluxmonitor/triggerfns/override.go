@@ -0,0 +1,62 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["override"] = FactoryFunc(overrideFactory)
+}
+
+// overrideTriggerFn fires when its inner function does, or whenever
+// tc.OverrideReport is set, so that operators can force a report. The inner
+// function is evaluated either way, so that a stateful one sees every answer.
+type overrideTriggerFn struct {
+	inner TriggerFn
+}
+
+// overrideFactory expects the spec of the inner function, e.g.
+// {"type": "relativeThreshold", "params": 0.01}.
+func overrideFactory(params interface{}) (TriggerFn, error) {
+	var p triggerFnJSON
+	if err := decodeParams("override", params, &p); err != nil {
+		return nil, err
+	}
+	inner, err := makeTriggerFn(p.Type, p.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing override's inner trigger function")
+	}
+	return overrideTriggerFn{inner: inner}, nil
+}
+
+func (o overrideTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := o.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	return fired || tc.OverrideReport, nil
+}
+
+func (o overrideTriggerFn) Clone() TriggerFn {
+	return overrideTriggerFn{inner: cloneTriggerFn(o.inner)}
+}
+
+func (o overrideTriggerFn) Parameters() interface{} {
+	return triggerFnJSON{Type: o.inner.Factory(), Params: o.inner.Parameters()}
+}
+
+func (o overrideTriggerFn) SetClock(clock utils.AfterNower) {
+	TriggerFns{o.inner}.SetClock(clock)
+}
+func (o overrideTriggerFn) Reset()              { TriggerFns{o.inner}.ResetAll() }
+func (o overrideTriggerFn) stateful() bool      { return isStateful(o.inner) }
+func (o overrideTriggerFn) Factory() string     { return "override" }
+func (o overrideTriggerFn) ParamSchema() string { return triggerFnSchemas["override"] }
+func (o overrideTriggerFn) String() string {
+	return "override(" + triggerFnString(o.inner) + ")"
+}
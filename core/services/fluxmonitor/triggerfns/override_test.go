@@ -0,0 +1,58 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverride(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"override": {"type": "or", "params": {"relativeThreshold": 0.01, "absoluteThreshold": 5}}}`))
+	ctx := context.Background()
+	current := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name          string
+		new           string
+		override      bool
+		wantTriggered bool
+	}{
+		{"inner triggers say no", "100.1", false, false},
+		{"override forces a report", "100.1", true, true},
+		{"override on an unchanged answer", "100", true, true},
+		{"inner triggers say yes", "110", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfns.ShouldReport(ctx, current, decimal.RequireFromString(test.new),
+				triggerfns.TriggerContext{OverrideReport: test.override})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestOverride_Parameters(t *testing.T) {
+	tfn := mustScanOne(t, `{"override": {"type": "relativeThreshold", "params": 0.01}}`)
+	assert.Equal(t, "override(relativeThreshold(0.01))", tfn.(interface{ String() string }).String())
+	params, err := json.Marshal(tfn.Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "relativeThreshold", "params": 0.01}`, string(params))
+}
+
+func TestOverride_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"override": true}`,
+		`{"override": {"type": "frobnicate", "params": 1}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
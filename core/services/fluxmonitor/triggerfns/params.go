@@ -0,0 +1,176 @@
+package triggerfns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// decodeParams decodes params, as parsed from a job spec, into out, which must
+// point to a struct. Each field is read from the key named by its json tag,
+// and is required unless the tag includes omitempty. Unknown keys are errors.
+//
+// For backwards compatibility with single-float factories, a struct with a
+// single float64 field may also be given as a bare number.
+func decodeParams(factory string, params interface{}, out interface{}) error {
+	fields := paramFields(out)
+	if len(fields) == 1 {
+		if number, ok := bareNumber(params); ok {
+			field := reflect.ValueOf(out).Elem().Field(fields[0].index)
+			if field.Kind() != reflect.Float64 {
+				return errors.Errorf("%s requires an object parameter, got %v", factory, params)
+			}
+			if math.IsNaN(number) || math.IsInf(number, 0) {
+				// Only params passed in directly can get here: JSON has no
+				// NaN or infinities.
+				return ParamError{Factory: factory, Value: params, Field: fields[0].name,
+					Err: fmt.Errorf("%w: %s requires a finite %s, got %v", ErrNotFinite, factory, fields[0].name, number)}
+			}
+			field.SetFloat(number)
+			return nil
+		}
+	}
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return errors.Wrapf(err, "while reading %s params", factory)
+	}
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(b, &keys); err != nil || keys == nil {
+		if len(fields) == 1 {
+			return errors.Errorf("%s requires a number or an object parameter, got %v", factory, params)
+		}
+		return errors.Errorf("%s requires an object parameter, got %v", factory, params)
+	}
+	for _, field := range fields {
+		if _, ok := keys[field.name]; field.required && !ok {
+			return ParamError{Factory: factory, Value: params, Field: field.name,
+				Err: errors.Errorf("%s requires a %s parameter", factory, field.name)}
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return decodeError(factory, params, err)
+	}
+	return nil
+}
+
+// decodeError describes err, from decoding params for factory, as a
+// ParamError naming the field at fault where it can.
+func decodeError(factory string, params interface{}, err error) error {
+	paramErr := ParamError{Factory: factory, Value: params, Err: errors.Wrapf(err, "%s has malformed params", factory)}
+	if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+		paramErr.Field = typeErr.Field
+		paramErr.Err = errors.Errorf("%s requires %s to be a %s, got %s",
+			factory, typeErr.Field, jsonTypeName(typeErr.Type), typeErr.Value)
+	} else if quoted := strings.TrimPrefix(err.Error(), "json: unknown field "); quoted != err.Error() {
+		if field, unquoteErr := strconv.Unquote(quoted); unquoteErr == nil {
+			paramErr.Field = field
+		}
+	}
+	return paramErr
+}
+
+// jsonTypeName names the JSON type which decodes to t.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+// paramField describes a field of a params struct.
+type paramField struct {
+	index    int
+	name     string
+	required bool
+}
+
+// paramFields lists the fields of the struct out points to which decodeParams
+// reads.
+func paramFields(out interface{}) []paramField {
+	t := reflect.TypeOf(out).Elem()
+	var fields []paramField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = f.Name
+		}
+		required := true
+		for _, option := range tag[1:] {
+			if option == "omitempty" {
+				required = false
+			}
+		}
+		fields = append(fields, paramField{index: i, name: name, required: required})
+	}
+	return fields
+}
+
+// bareNumber returns params as a float64 if it is a number, whether parsed
+// as a float64 or kept as a json.Number.
+func bareNumber(params interface{}) (float64, bool) {
+	switch number := params.(type) {
+	case float64:
+		return number, true
+	case json.Number:
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// plainNumbers returns params with every json.Number in it replaced by a
+// float64, for factories which expect numbers to be parsed as usual.
+func plainNumbers(params interface{}) interface{} {
+	switch v := params.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v
+		}
+		return f
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = plainNumbers(value)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, value := range v {
+			a[i] = plainNumbers(value)
+		}
+		return a
+	}
+	return params
+}
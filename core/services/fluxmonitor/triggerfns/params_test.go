@@ -0,0 +1,79 @@
+package triggerfns_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exampleParams struct {
+	Upper float64 `json:"upper"`
+	Lower float64 `json:"lower"`
+	Label string  `json:"label,omitempty"`
+}
+
+// parsed returns params as a trigger factory would receive them from Scan.
+func parsed(t *testing.T, params string) interface{} {
+	t.Helper()
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(params), &v))
+	return v
+}
+
+func TestDecodeParams_Object(t *testing.T) {
+	var p exampleParams
+	require.NoError(t, triggerfns.ExportedDecodeParams("band",
+		parsed(t, `{"upper": 0.02, "lower": 0.01, "label": "eth"}`), &p))
+	assert.Equal(t, exampleParams{Upper: 0.02, Lower: 0.01, Label: "eth"}, p)
+
+	p = exampleParams{}
+	require.NoError(t, triggerfns.ExportedDecodeParams("band", parsed(t, `{"upper": 0.02, "lower": 0}`), &p))
+	assert.Equal(t, exampleParams{Upper: 0.02}, p)
+}
+
+func TestDecodeParams_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		wantErr string
+	}{
+		{"missing field", `{"upper": 0.02}`, "band requires a lower parameter"},
+		{"bare number", `0.02`, "band requires an object parameter, got 0.02"},
+		{"wrong type", `{"upper": "2%", "lower": 0.01}`, "band requires upper to be a number, got string"},
+		{"unknown field", `{"upper": 0.02, "lower": 0.01, "middle": 0.015}`, "band has malformed params"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var p exampleParams
+			err := triggerfns.ExportedDecodeParams("band", parsed(t, test.params), &p)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func TestDecodeParams_Scalar(t *testing.T) {
+	var p struct {
+		Threshold float64 `json:"threshold"`
+	}
+	require.NoError(t, triggerfns.ExportedDecodeParams("relativeThreshold", 0.5, &p))
+	assert.Equal(t, 0.5, p.Threshold)
+
+	require.NoError(t, triggerfns.ExportedDecodeParams("relativeThreshold", parsed(t, `{"threshold": 0.25}`), &p))
+	assert.Equal(t, 0.25, p.Threshold)
+
+	err := triggerfns.ExportedDecodeParams("relativeThreshold", "0.5", &p)
+	assert.EqualError(t, err, "relativeThreshold requires a number or an object parameter, got 0.5")
+}
+
+func TestRelativeThreshold_AcceptsObjectParams(t *testing.T) {
+	var scalar, object triggerfns.TriggerFns
+	require.NoError(t, scalar.Scan(`{"relativeThreshold": 0.01}`))
+	require.NoError(t, object.Scan(`[{"type": "relativeThreshold", "params": {"threshold": 0.01}}]`))
+	assert.True(t, scalar.Equals(object))
+	assert.Equal(t, 0.01, object[0].Parameters())
+}
@@ -0,0 +1,31 @@
+package triggerfns
+
+// RelativeThreshold returns a relativeThreshold, which fires when new differs
+// from current by at least threshold, as a fraction of current, so that 0.01
+// means 1%. It is for building trigger functions in Go, and panics if
+// threshold is negative or not finite.
+func RelativeThreshold(threshold float64) TriggerFn {
+	return mustTriggerFn(relativeThresholdFactory(threshold))
+}
+
+// AbsoluteThreshold returns an absoluteThreshold, which fires when new differs
+// from current by at least delta. It panics if delta is negative or not
+// finite.
+func AbsoluteThreshold(delta float64) TriggerFn {
+	return mustTriggerFn(absoluteThresholdFactory(delta))
+}
+
+// Staleness returns a staleness function, which fires once seconds have
+// passed since the last report. It panics unless seconds is positive.
+func Staleness(seconds float64) TriggerFn {
+	return mustTriggerFn(stalenessThresholdFactory(seconds))
+}
+
+// With returns f with tfns appended, leaving f itself unmodified, so that
+// sets can be built up as
+//
+//	TriggerFns{}.With(RelativeThreshold(0.005), Staleness(3600))
+func (f TriggerFns) With(tfns ...TriggerFn) TriggerFns {
+	with := make(TriggerFns, 0, len(f)+len(tfns))
+	return append(append(with, f...), tfns...)
+}
@@ -0,0 +1,45 @@
+package triggerfns_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresets_EqualFactoryBuilt(t *testing.T) {
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(`{"relativeThreshold": 0.005, "absoluteThreshold": 2.5, "staleness": 3600}`))
+
+	built := triggerfns.TriggerFns{}.With(
+		triggerfns.RelativeThreshold(0.005),
+		triggerfns.AbsoluteThreshold(2.5),
+		triggerfns.Staleness(3600),
+	)
+	assert.True(t, built.Equals(scanned), "%v != %v", built, scanned)
+	require.NoError(t, built.Validate())
+
+	assert.False(t, triggerfns.TriggerFns{triggerfns.RelativeThreshold(0.01)}.Equals(
+		scanned.FilterByFactory("relativeThreshold")))
+}
+
+func TestPresets_PanicOnInvalid(t *testing.T) {
+	assert.Panics(t, func() { triggerfns.RelativeThreshold(-0.01) })
+	assert.Panics(t, func() { triggerfns.RelativeThreshold(math.NaN()) })
+	assert.Panics(t, func() { triggerfns.AbsoluteThreshold(math.Inf(1)) })
+	assert.Panics(t, func() { triggerfns.Staleness(0) })
+}
+
+func TestTriggerFns_With(t *testing.T) {
+	base := triggerfns.TriggerFns{triggerfns.RelativeThreshold(0.01)}
+	a := base.With(triggerfns.Staleness(60))
+	b := base.With(triggerfns.AbsoluteThreshold(1))
+
+	assert.Equal(t, "relativeThreshold(0.01)", base.String())
+	assert.Equal(t, "relativeThreshold(0.01), staleness(60)", a.String())
+	assert.Equal(t, "relativeThreshold(0.01), absoluteThreshold(1)", b.String())
+	assert.True(t, base.With().Equals(base))
+}
@@ -0,0 +1,195 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+)
+
+func init() {
+	triggerFnFactories["quorum"] = FactoryFunc(quorumFactory)
+	triggerFnFactories["weightedQuorum"] = FactoryFunc(weightedQuorumFactory)
+}
+
+// quorumParams are the params of quorum, e.g.
+// {"threshold": 2, "triggers": {"relativeThreshold": 0.01, "staleness": 60, ...}}.
+type quorumParams struct {
+	Threshold int                    `json:"threshold"`
+	Triggers  map[string]interface{} `json:"triggers"`
+}
+
+// quorumTriggerFn fires when at least threshold of its inner functions fire.
+// Every inner function is evaluated on every answer.
+type quorumTriggerFn struct {
+	threshold int
+	fns       TriggerFns
+}
+
+func quorumFactory(params interface{}) (TriggerFn, error) {
+	var p quorumParams
+	if err := decodeParams("quorum", params, &p); err != nil {
+		return nil, err
+	}
+	fns, err := makeInnerTriggerFns("quorum", p.Triggers)
+	if err != nil {
+		return nil, err
+	}
+	if p.Threshold < 1 || p.Threshold > len(fns) {
+		return nil, errors.Errorf("quorum requires a threshold between 1 and its %d inner trigger functions, got %d",
+			len(fns), p.Threshold)
+	}
+	return quorumTriggerFn{threshold: p.Threshold, fns: fns}, nil
+}
+
+// Triggering returns every error from the inner functions, combined, if any
+// of them fails.
+func (q quorumTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	var merr error
+	count := 0
+	for _, tfn := range q.fns {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		fired, err := tfn.Triggering(ctx, current, new, tc)
+		if err != nil {
+			merr = multierr.Append(merr, errors.Wrap(err, tfn.Factory()))
+			continue
+		}
+		if fired {
+			count++
+		}
+	}
+	if merr != nil {
+		return false, merr
+	}
+	return count >= q.threshold, nil
+}
+
+// Clone returns a quorum of clones of q's inner functions.
+func (q quorumTriggerFn) Clone() TriggerFn {
+	return quorumTriggerFn{threshold: q.threshold, fns: q.fns.Clone()}
+}
+
+func (q quorumTriggerFn) Parameters() interface{} {
+	return quorumParams{Threshold: q.threshold, Triggers: innerParameters(q.fns)}
+}
+
+func (q quorumTriggerFn) SetClock(clock utils.AfterNower) { q.fns.SetClock(clock) }
+func (q quorumTriggerFn) stateful() bool                  { return compositeTriggerFn{fns: q.fns}.stateful() }
+func (q quorumTriggerFn) Factory() string                 { return "quorum" }
+func (q quorumTriggerFn) ParamSchema() string             { return triggerFnSchemas["quorum"] }
+func (q quorumTriggerFn) String() string {
+	return fmt.Sprintf("quorum(%d of %s)", q.threshold, q.fns.String())
+}
+
+// weightedQuorumParams are the params of weightedQuorum, e.g.
+// {"weightThreshold": 3, "triggers": [{"weight": 2, "type": "relativeThreshold", "params": 0.01}, ...]}.
+type weightedQuorumParams struct {
+	WeightThreshold float64                 `json:"weightThreshold"`
+	Triggers        []weightedTriggerFnJSON `json:"triggers"`
+}
+
+// weightedTriggerFnJSON is an inner function of weightedQuorum, with its
+// weight.
+type weightedTriggerFnJSON struct {
+	Weight float64     `json:"weight"`
+	Type   string      `json:"type"`
+	Params interface{} `json:"params"`
+}
+
+// weightedQuorumTriggerFn fires when the weights of those of its inner
+// functions which fire add up to at least weightThreshold. Every inner
+// function is evaluated on every answer.
+type weightedQuorumTriggerFn struct {
+	weightThreshold float64
+	weights         []float64
+	fns             TriggerFns
+}
+
+func weightedQuorumFactory(params interface{}) (TriggerFn, error) {
+	var p weightedQuorumParams
+	if err := decodeParams("weightedQuorum", params, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Triggers) == 0 {
+		return nil, errors.New("weightedQuorum requires at least one inner trigger function")
+	}
+	q := weightedQuorumTriggerFn{weightThreshold: p.WeightThreshold}
+	total := 0.0
+	for i, inner := range p.Triggers {
+		if !(inner.Weight > 0) || math.IsInf(inner.Weight, 0) {
+			return nil, errors.Errorf("weightedQuorum requires positive, finite weights, got %v for trigger %d",
+				inner.Weight, i)
+		}
+		tfn, err := makeTriggerFn(inner.Type, inner.Params)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while constructing weightedQuorum's trigger %d", i)
+		}
+		q.weights = append(q.weights, inner.Weight)
+		q.fns = append(q.fns, tfn)
+		total += inner.Weight
+	}
+	if !(p.WeightThreshold > 0) || p.WeightThreshold > total {
+		return nil, errors.Errorf("weightedQuorum requires a weightThreshold above 0 and at most its total weight of %v, got %v",
+			total, p.WeightThreshold)
+	}
+	return q, nil
+}
+
+// Triggering returns every error from the inner functions, combined, if any
+// of them fails.
+func (q weightedQuorumTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	var merr error
+	weight := 0.0
+	for i, tfn := range q.fns {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		fired, err := tfn.Triggering(ctx, current, new, tc)
+		if err != nil {
+			merr = multierr.Append(merr, errors.Wrap(err, tfn.Factory()))
+			continue
+		}
+		if fired {
+			weight += q.weights[i]
+		}
+	}
+	if merr != nil {
+		return false, merr
+	}
+	return weight >= q.weightThreshold, nil
+}
+
+// Clone returns a weighted quorum of clones of q's inner functions.
+func (q weightedQuorumTriggerFn) Clone() TriggerFn {
+	return weightedQuorumTriggerFn{weightThreshold: q.weightThreshold, weights: q.weights, fns: q.fns.Clone()}
+}
+
+func (q weightedQuorumTriggerFn) Parameters() interface{} {
+	p := weightedQuorumParams{WeightThreshold: q.weightThreshold}
+	for i, tfn := range q.fns {
+		p.Triggers = append(p.Triggers, weightedTriggerFnJSON{
+			Weight: q.weights[i], Type: tfn.Factory(), Params: tfn.Parameters()})
+	}
+	return p
+}
+
+func (q weightedQuorumTriggerFn) SetClock(clock utils.AfterNower) { q.fns.SetClock(clock) }
+func (q weightedQuorumTriggerFn) Reset()                          { q.fns.ResetAll() }
+func (q weightedQuorumTriggerFn) stateful() bool                  { return compositeTriggerFn{fns: q.fns}.stateful() }
+func (q weightedQuorumTriggerFn) Factory() string                 { return "weightedQuorum" }
+func (q weightedQuorumTriggerFn) ParamSchema() string             { return triggerFnSchemas["weightedQuorum"] }
+func (q weightedQuorumTriggerFn) String() string {
+	inner := make([]string, len(q.fns))
+	for i, tfn := range q.fns {
+		inner[i] = fmt.Sprintf("%s weight %v", triggerFnString(tfn), q.weights[i])
+	}
+	return fmt.Sprintf("weightedQuorum(%v of [%s])", q.weightThreshold, strings.Join(inner, ", "))
+}
@@ -0,0 +1,182 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+const twoOfThree = `{"quorum": {"threshold": 2, "triggers": {
+	"relativeThreshold": 0.01,
+	"absoluteThreshold": 5,
+	"increaseThreshold": 2
+}}}`
+
+func TestQuorum_TwoOfThree(t *testing.T) {
+	tfn := mustScanOne(t, twoOfThree)
+
+	tests := []struct {
+		name          string
+		new           string
+		wantTriggered bool
+	}{
+		{"none fire", "100.5", false},
+		{"only relativeThreshold fires", "97", false},
+		{"relativeThreshold and increaseThreshold fire", "103", true},
+		{"relativeThreshold and absoluteThreshold fire", "94", true},
+		{"all three fire", "106", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100),
+				decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestQuorum_AggregatesErrors(t *testing.T) {
+	tfn := mustScanOne(t, `{"quorum": {"threshold": 1, "triggers": {
+		"staleness": 60,
+		"percentOfReference": 1,
+		"relativeThreshold": 0.01
+	}}}`)
+
+	_, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(200), triggerfns.TriggerContext{})
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "percentOfReference: percentOfReference requires a reference value")
+	assert.EqualError(t, errs[1], "staleness: staleness requires the last report time")
+
+	ref := decimal.NewFromInt(100)
+	fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(100),
+		triggerfns.TriggerContext{LastReportedAt: time.Now(), Reference: &ref})
+	require.NoError(t, err)
+	assert.False(t, fired)
+}
+
+func TestQuorum_ValueScanRoundTrip(t *testing.T) {
+	original := triggerfns.TriggerFns{mustScanOne(t, twoOfThree)}
+	params, err := json.Marshal(original[0].Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"threshold": 2, "triggers": {
+		"absoluteThreshold": 5, "increaseThreshold": 2, "relativeThreshold": 0.01
+	}}`, string(params))
+	assert.Equal(t, "quorum(2 of absoluteThreshold(5), increaseThreshold(2), relativeThreshold(0.01))", original.String())
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, original.Equals(scanned))
+}
+
+func TestQuorum_BadParams(t *testing.T) {
+	for _, bad := range []string{
+		`{"quorum": {"threshold": 0, "triggers": {"relativeThreshold": 0.01}}}`,
+		`{"quorum": {"threshold": 2, "triggers": {"relativeThreshold": 0.01}}}`,
+		`{"quorum": {"threshold": 1, "triggers": {}}}`,
+		`{"quorum": {"threshold": 1}}`,
+		`{"quorum": {"threshold": 1, "triggers": {"frobnicate": 1}}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(bad), bad)
+	}
+}
+
+const weighted = `{"weightedQuorum": {"weightThreshold": 3, "triggers": [
+	{"weight": 3, "type": "absoluteThreshold", "params": 50},
+	{"weight": 1, "type": "relativeThreshold", "params": 0.01},
+	{"weight": 1, "type": "increaseThreshold", "params": 2},
+	{"weight": 1, "type": "decreaseThreshold", "params": 2}
+]}}`
+
+func TestWeightedQuorum(t *testing.T) {
+	tfn := mustScanOne(t, weighted)
+
+	tests := []struct {
+		name          string
+		current, new  int64
+		wantTriggered bool
+	}{
+		{"heavy trigger alone", 10000, 10060, true},
+		{"two light triggers rising are not enough", 100, 102, false},
+		{"two light triggers falling are not enough", 100, 98, false},
+		{"nothing fires", 100, 100, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(test.current), decimal.NewFromInt(test.new),
+				triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestWeightedQuorum_LightTriggersCombine(t *testing.T) {
+	tfn := mustScanOne(t, `{"weightedQuorum": {"weightThreshold": 2.5, "triggers": [
+		{"weight": 5, "type": "absoluteThreshold", "params": 50},
+		{"weight": 1, "type": "relativeThreshold", "params": 0.01},
+		{"weight": 1, "type": "increaseThreshold", "params": 2},
+		{"weight": 1, "type": "absoluteThreshold", "params": 3}
+	]}}`)
+
+	// relativeThreshold and increaseThreshold fire, weighing 2.
+	fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromFloat(102.5), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+	// All three light triggers fire, weighing 3.
+	fired, err = tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(104), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestWeightedQuorum_ValueScanRoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(weighted))
+	require.NoError(t, tfns.Validate())
+	assert.Equal(t, "weightedQuorum(3 of [absoluteThreshold(50) weight 3, relativeThreshold(0.01) weight 1, "+
+		"increaseThreshold(2) weight 1, decreaseThreshold(2) weight 1])", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	var rescanned triggerfns.TriggerFns
+	require.NoError(t, rescanned.Scan(value))
+	assert.True(t, tfns.Equals(rescanned), "got %s", rescanned)
+	params, err := json.Marshal(rescanned[0].Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"weightThreshold": 3, "triggers": [
+		{"weight": 3, "type": "absoluteThreshold", "params": 50},
+		{"weight": 1, "type": "relativeThreshold", "params": 0.01},
+		{"weight": 1, "type": "increaseThreshold", "params": 2},
+		{"weight": 1, "type": "decreaseThreshold", "params": 2}
+	]}`, string(params))
+}
+
+func TestWeightedQuorum_BadParams(t *testing.T) {
+	for _, bad := range []string{
+		`{"weightedQuorum": {"weightThreshold": 0, "triggers": [{"weight": 1, "type": "relativeThreshold", "params": 0.01}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 2, "triggers": [{"weight": 1, "type": "relativeThreshold", "params": 0.01}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": [{"weight": 0, "type": "relativeThreshold", "params": 0.01}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": [{"weight": -1, "type": "relativeThreshold", "params": 0.01}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": [{"type": "relativeThreshold", "params": 0.01}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": [{"weight": 1, "type": "frobnicate", "params": 1}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": [{"weight": 1, "type": "relativeThreshold", "params": 0.01, "extra": 1}]}}`,
+		`{"weightedQuorum": {"weightThreshold": 1, "triggers": []}}`,
+		`{"weightedQuorum": {"weightThreshold": 1}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(bad), bad)
+	}
+}
@@ -0,0 +1,129 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["rateLimit"] = FactoryFunc(rateLimitFactory)
+}
+
+// rateLimitParams are the params of rateLimit, e.g.
+// {"maxReports": 4, "window": "1h", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type rateLimitParams struct {
+	MaxReports uint32        `json:"maxReports"`
+	Window     duration      `json:"window"`
+	TriggerFn  triggerFnJSON `json:"triggerFn"`
+}
+
+// rateLimitTriggerFn fires when its inner function does, at most maxReports
+// times per window. Unlike cooldown, which spaces out every pair of fires, it
+// allows a burst of up to maxReports fires, and then one more for each
+// window/maxReports that passes. It is a token bucket holding maxReports
+// tokens, which starts full and refills continuously over window. The inner
+// function is evaluated either way, so that a stateful one sees every answer.
+type rateLimitTriggerFn struct {
+	maxReports uint32
+	window     time.Duration
+	inner      TriggerFn
+	clock      utils.AfterNower
+
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+func rateLimitFactory(params interface{}) (TriggerFn, error) {
+	var p rateLimitParams
+	if err := decodeParams("rateLimit", params, &p); err != nil {
+		return nil, err
+	}
+	if p.MaxReports == 0 {
+		return nil, errors.New("rateLimit requires a positive maxReports")
+	}
+	if p.Window == 0 {
+		return nil, errors.New("rateLimit requires a positive window")
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing rateLimit's inner trigger function")
+	}
+	return newRateLimit(p.MaxReports, time.Duration(p.Window), inner, utils.Clock{}), nil
+}
+
+func newRateLimit(maxReports uint32, window time.Duration, inner TriggerFn, clock utils.AfterNower) *rateLimitTriggerFn {
+	return &rateLimitTriggerFn{
+		maxReports: maxReports,
+		window:     window,
+		inner:      inner,
+		clock:      clock,
+		tokens:     float64(maxReports),
+	}
+}
+
+func (r *rateLimitTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := r.inner.Triggering(ctx, current, new, tc)
+	if err != nil || !fired {
+		return false, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	if !r.lastRefill.IsZero() && now.After(r.lastRefill) {
+		refill := float64(now.Sub(r.lastRefill)) / float64(r.window) * float64(r.maxReports)
+		r.tokens = math.Min(float64(r.maxReports), r.tokens+refill)
+	}
+	r.lastRefill = now
+	if r.tokens < 1 {
+		return false, nil
+	}
+	r.tokens--
+	return true, nil
+}
+
+// SetClock sets the clock r refills its bucket by, and passes it on to the
+// inner function.
+func (r *rateLimitTriggerFn) SetClock(clock utils.AfterNower) {
+	r.mu.Lock()
+	r.clock = clock
+	r.mu.Unlock()
+	TriggerFns{r.inner}.SetClock(clock)
+}
+
+// Clone returns a rateLimit with a full bucket, wrapping a clone of r's inner
+// function.
+func (r *rateLimitTriggerFn) Clone() TriggerFn {
+	return newRateLimit(r.maxReports, r.window, cloneTriggerFn(r.inner), r.clock)
+}
+
+// Reset refills r's bucket, and resets its inner function.
+func (r *rateLimitTriggerFn) Reset() {
+	r.mu.Lock()
+	r.tokens, r.lastRefill = float64(r.maxReports), time.Time{}
+	r.mu.Unlock()
+	TriggerFns{r.inner}.ResetAll()
+}
+
+func (r *rateLimitTriggerFn) Parameters() interface{} {
+	return rateLimitParams{
+		MaxReports: r.maxReports,
+		Window:     duration(r.window),
+		TriggerFn:  triggerFnJSON{Type: r.inner.Factory(), Params: r.inner.Parameters()},
+	}
+}
+
+func (r *rateLimitTriggerFn) stateful() bool      { return isStateful(r.inner) }
+func (r *rateLimitTriggerFn) Factory() string     { return "rateLimit" }
+func (r *rateLimitTriggerFn) ParamSchema() string { return triggerFnSchemas["rateLimit"] }
+func (r *rateLimitTriggerFn) String() string {
+	return fmt.Sprintf("rateLimit(%d per %s, %s)", r.maxReports, r.window, triggerFnString(r.inner))
+}
@@ -0,0 +1,89 @@
+package triggerfns_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rateLimitSpec = `[{"type": "rateLimit", "params": {
+	"maxReports": 2,
+	"window": "1h",
+	"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+}}]`
+
+func TestRateLimit_ExhaustsAndRefills(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(rateLimitSpec))
+
+	fired := pollAt(t, tfns, []time.Duration{
+		0,
+		time.Minute,
+		// The bucket is empty, and has refilled by only a fifteenth of a
+		// report.
+		3 * time.Minute,
+		// A report's worth has refilled after half the window.
+		32 * time.Minute,
+		33 * time.Minute,
+		// The whole bucket has refilled after the window.
+		2 * time.Hour,
+		2*time.Hour + time.Second,
+		2*time.Hour + 2*time.Second,
+	})
+	assert.Equal(t, []bool{true, true, false, true, false, true, true, false}, fired)
+}
+
+func TestRateLimit_BucketDoesNotOverfill(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(rateLimitSpec))
+
+	// A long quiet spell still only allows maxReports fires.
+	fired := pollAt(t, tfns, []time.Duration{0, 24 * time.Hour, 24*time.Hour + 1, 24*time.Hour + 2})
+	assert.Equal(t, []bool{true, true, true, false}, fired)
+}
+
+func TestRateLimit_CloneAndReset(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(rateLimitSpec))
+	assert.Equal(t, []bool{true, true, false}, pollAt(t, tfns, []time.Duration{0, 1, 2}))
+
+	// The clone's bucket is full.
+	assert.Equal(t, []bool{true, true, false}, pollAt(t, tfns.Clone(), []time.Duration{3, 4, 5}))
+
+	tfns.ResetAll()
+	assert.Equal(t, []bool{true, true, false}, pollAt(t, tfns, []time.Duration{6, 7, 8}))
+}
+
+func TestRateLimit_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(rateLimitSpec))
+	assert.Equal(t, "rateLimit(2 per 1h0m0s, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "rateLimit", "params": {
+		"maxReports": 2,
+		"window": "1h0m0s",
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}]}`, string(value.([]byte)))
+
+	var rescanned triggerfns.TriggerFns
+	require.NoError(t, rescanned.Scan(value))
+	assert.Equal(t, tfns.String(), rescanned.String())
+}
+
+func TestRateLimit_InvalidParams(t *testing.T) {
+	for _, params := range []string{
+		`{"maxReports": 0, "window": "1h", "triggerFn": {"type": "staleness", "params": 60}}`,
+		`{"maxReports": 2, "window": "0s", "triggerFn": {"type": "staleness", "params": 60}}`,
+		`{"maxReports": 2, "window": "-1h", "triggerFn": {"type": "staleness", "params": 60}}`,
+		`{"maxReports": 2, "window": "1h", "triggerFn": {"type": "nonexistent", "params": 60}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(`{"rateLimit": `+params+`}`), params)
+	}
+}
@@ -0,0 +1,93 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// TriggerResult is the outcome of a trigger function's evaluation, with an
+// explanation suitable for audit logs.
+type TriggerResult struct {
+	Fired bool
+	// Reason explains the outcome, e.g. "relative deviation 0.60% >= 0.50%".
+	Reason string
+	// Deviation is the quantity the function compared against its threshold,
+	// or, for functions which don't report one, the relative deviation of new
+	// from current.
+	Deviation decimal.Decimal
+}
+
+// Reasoner is implemented by trigger functions which can explain their
+// outcome. TriggeringWithReason must agree with Triggering.
+type Reasoner interface {
+	TriggeringWithReason(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (TriggerResult, error)
+}
+
+// TriggeringWithReason evaluates tfn, explaining the outcome if tfn is a
+// Reasoner, and otherwise reporting only whether it fired.
+func TriggeringWithReason(ctx context.Context, tfn TriggerFn, current, new decimal.Decimal, tc TriggerContext) (TriggerResult, error) {
+	if r, ok := tfn.(Reasoner); ok {
+		return r.TriggeringWithReason(ctx, current, new, tc)
+	}
+	fired, err := tfn.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return TriggerResult{}, err
+	}
+	reason := tfn.Factory() + " did not fire"
+	if fired {
+		reason = tfn.Factory() + " fired"
+	}
+	return TriggerResult{Fired: fired, Reason: reason, Deviation: RelativeDeviation(current, new)}, nil
+}
+
+// thresholdMeasure is the quantity a thresholdTriggerFn compares against its
+// threshold.
+type thresholdMeasure struct {
+	name string
+	of   func(current, new decimal.Decimal) decimal.Decimal
+	// percent is true if the measure and threshold are fractions, which
+	// reasons show as percentages.
+	percent bool
+	// ofNew is true if a percent measure is a fraction of new, rather than
+	// of current.
+	ofNew bool
+}
+
+var (
+	relativeMeasure      = thresholdMeasure{name: "relative deviation", of: RelativeDeviation, percent: true}
+	midpointMeasure      = thresholdMeasure{name: "midpoint deviation", of: MidpointDeviation, percent: true}
+	relativeToNewMeasure = thresholdMeasure{name: "relative deviation to new", of: func(current, new decimal.Decimal) decimal.Decimal {
+		return RelativeDeviation(new, current)
+	}, percent: true, ofNew: true}
+	absoluteMeasure = thresholdMeasure{name: "absolute deviation", of: AbsoluteDeviation}
+	increaseMeasure = thresholdMeasure{name: "increase", of: func(current, new decimal.Decimal) decimal.Decimal {
+		return new.Sub(current)
+	}}
+	decreaseMeasure = thresholdMeasure{name: "decrease", of: func(current, new decimal.Decimal) decimal.Decimal {
+		return current.Sub(new)
+	}}
+)
+
+// reason explains a comparison of deviation, as computed by m from current
+// and new, against threshold.
+func (m thresholdMeasure) reason(fired bool, current, new, deviation, threshold decimal.Decimal) string {
+	cmp := "<"
+	if fired {
+		cmp = ">="
+	}
+	if m.percent {
+		name, base := m.name, current
+		if m.ofNew {
+			base = new
+		}
+		if base.IsZero() {
+			name += " from zero"
+		}
+		hundred := decimal.NewFromInt(100)
+		return fmt.Sprintf("%s %s%% %s %s%%", name,
+			deviation.Mul(hundred).StringFixed(2), cmp, threshold.Mul(hundred).StringFixed(2))
+	}
+	return fmt.Sprintf("%s %s %s %s", m.name, deviation, cmp, threshold)
+}
@@ -0,0 +1,70 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggeringWithReason(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		current, new  string
+		wantFired     bool
+		wantReason    string
+		wantDeviation string
+	}{
+		{"relative fires", `{"relativeThreshold": 0.005}`, "100", "100.6", true, "relative deviation 0.60% >= 0.50%", "0.006"},
+		{"relative suppressed", `{"relativeThreshold": 0.005}`, "100", "99.6", false, "relative deviation 0.40% < 0.50%", "0.004"},
+		{"relative from zero", `{"relativeThreshold": 0.005}`, "0", "1", true, "relative deviation from zero 100.00% >= 0.50%", "1"},
+		{"relative from zero, negative new", `{"relativeThreshold": 0.005}`, "0", "-1", true, "relative deviation from zero 100.00% >= 0.50%", "1"},
+		{"relative from zero above 100%", `{"relativeThreshold": 1.5}`, "0", "1", false, "relative deviation from zero 100.00% < 150.00%", "1"},
+		{"bps", `{"relativeBps": 25}`, "100", "100.25", true, "relative deviation 0.25% >= 0.25%", "0.0025"},
+		{"absolute", `{"absoluteThreshold": 2}`, "10", "7", true, "absolute deviation 3 >= 2", "3"},
+		{"increase", `{"increaseThreshold": 2}`, "10", "7", false, "increase -3 < 2", "-3"},
+		{"decrease", `{"decreaseThreshold": 2}`, "10", "7", true, "decrease 3 >= 2", "3"},
+		{"no reason given", `{"floor": 8}`, "10", "7", true, "floor fired", "0.3"},
+		{"no reason given suppressed", `{"ceiling": 8}`, "10", "7", false, "ceiling did not fire", "0.3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tfn := mustScanOne(t, test.spec)
+			current, new := decimal.RequireFromString(test.current), decimal.RequireFromString(test.new)
+			result, err := triggerfns.TriggeringWithReason(context.Background(), tfn, current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantFired, result.Fired)
+			assert.Equal(t, test.wantReason, result.Reason)
+			assert.Equal(t, test.wantDeviation, result.Deviation.String())
+
+			fired, err := tfn.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, fired, result.Fired)
+		})
+	}
+}
+
+func TestTriggeringWithReason_Errors(t *testing.T) {
+	tfn := mustScanOne(t, `{"percentOfReference": 2}`)
+	_, err := triggerfns.TriggeringWithReason(context.Background(), tfn,
+		decimal.NewFromInt(100), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "percentOfReference requires a reference value")
+}
+
+func TestTriggeringWithReason_Metered(t *testing.T) {
+	tfns, err := triggerfns.TriggerFns{mustScanOne(t, `{"relativeThreshold": 0.005}`)}.
+		WithMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.Implements(t, (*triggerfns.Reasoner)(nil), tfns[0])
+
+	result, err := triggerfns.TriggeringWithReason(context.Background(), tfns[0],
+		decimal.NewFromInt(100), decimal.RequireFromString("100.6"), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.Equal(t, "relative deviation 0.60% >= 0.50%", result.Reason)
+}
@@ -0,0 +1,83 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// reentryParams are the params of reentry, e.g. {"min": 0.99, "max": "1.01"}.
+// Like submissionBounds's, the bounds may be numbers or decimal strings.
+type reentryParams struct {
+	Min interface{} `json:"min"`
+	Max interface{} `json:"max"`
+}
+
+// reentryTriggerFn fires when an answer comes back into [min, max] after the
+// one before it was outside, and at no other time: not on leaving the band,
+// nor while staying in or out of it. The first answer it is shown is compared
+// with current, as it has seen nothing earlier.
+type reentryTriggerFn struct {
+	params   reentryParams
+	min, max decimal.Decimal
+
+	mu   sync.Mutex
+	seen bool // whether out holds anything yet
+	out  bool // whether the last answer shown was outside the band
+}
+
+func reentryFactory(params interface{}) (TriggerFn, error) {
+	var p reentryParams
+	if err := decodeParams("reentry", params, &p); err != nil {
+		return nil, err
+	}
+	min, err := decimalParam("reentry", "min", p.Min)
+	if err != nil {
+		return nil, err
+	}
+	max, err := decimalParam("reentry", "max", p.Max)
+	if err != nil {
+		return nil, err
+	}
+	if min.GreaterThan(max) {
+		return nil, errors.Errorf("reentry requires min to be at most max, got %s and %s", min, max)
+	}
+	return &reentryTriggerFn{params: p, min: min, max: max}, nil
+}
+
+func (r *reentryTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wasOut := r.out
+	if !r.seen {
+		wasOut = !r.inBand(current)
+	}
+	r.seen, r.out = true, !r.inBand(new)
+	return wasOut && !r.out, nil
+}
+
+func (r *reentryTriggerFn) inBand(answer decimal.Decimal) bool {
+	return !answer.LessThan(r.min) && !answer.GreaterThan(r.max)
+}
+
+// Clone returns a reentry function with r's band which has seen no answers.
+func (r *reentryTriggerFn) Clone() TriggerFn {
+	return &reentryTriggerFn{params: r.params, min: r.min, max: r.max}
+}
+
+func (r *reentryTriggerFn) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen, r.out = false, false
+}
+
+func (r *reentryTriggerFn) stateful() bool          { return true }
+func (r *reentryTriggerFn) Factory() string         { return "reentry" }
+func (r *reentryTriggerFn) Parameters() interface{} { return r.params }
+func (r *reentryTriggerFn) ParamSchema() string     { return triggerFnSchemas["reentry"] }
+func (r *reentryTriggerFn) String() string {
+	return fmt.Sprintf("reentry(%s, %s)", r.min, r.max)
+}
@@ -0,0 +1,84 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reentrySpec = `{"reentry": {"min": 99, "max": "101"}}`
+
+func TestReentry(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(reentrySpec))
+
+	fired := pollSequence(t, tfns[0], 100, []float64{
+		100, 100.5, // staying in band
+		102, 103, // leaving, and staying out
+		101,     // re-entering at the bound
+		100, 99, // staying in
+		98.9,    // leaving below
+		99.5,    // re-entering
+		105, 95, // jumping across the band never lands in it
+		100,
+	})
+	assert.Equal(t, []bool{
+		false, false,
+		false, false,
+		true,
+		false, false,
+		false,
+		true,
+		false, false,
+		true,
+	}, fired)
+}
+
+func TestReentry_FirstAnswerComparedWithCurrent(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(reentrySpec))
+	assert.Equal(t, []bool{true, false}, pollSequence(t, tfns[0], 110, []float64{100, 100}))
+
+	require.NoError(t, tfns.Scan(reentrySpec))
+	assert.Equal(t, []bool{false}, pollSequence(t, tfns[0], 100, []float64{100}))
+}
+
+func TestReentry_CloneAndReset(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(reentrySpec))
+	assert.Equal(t, []bool{false}, pollSequence(t, tfns[0], 100, []float64{110}))
+
+	// The clone hasn't seen 110, so from an in-band current, 100 isn't a
+	// re-entry.
+	clone := tfns.Clone()
+	assert.Equal(t, []bool{false}, pollSequence(t, clone[0], 100, []float64{100}))
+
+	tfns.ResetAll()
+	assert.Equal(t, []bool{false}, pollSequence(t, tfns[0], 100, []float64{100}))
+}
+
+func TestReentry_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(reentrySpec))
+	assert.Equal(t, "reentry(99, 101)", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "reentry", "params": {"min": 99, "max": "101"}}]}`,
+		string(value.([]byte)))
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, tfns.Equals(scanned))
+
+	for _, spec := range []string{
+		`{"reentry": 100}`,
+		`{"reentry": {"min": 101, "max": 99}}`,
+		`{"reentry": {"min": "low", "max": 99}}`,
+		`{"reentry": {"min": 99}}`,
+	} {
+		assert.Error(t, scanned.Scan(spec), spec)
+	}
+}
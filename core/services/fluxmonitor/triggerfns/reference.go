@@ -0,0 +1,46 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// percentOfReferenceTriggerFn fires when new differs from tc.Reference by at
+// least pct percent of the reference, whatever the current answer is. Like
+// relativeThreshold, it fires on any nonzero answer when the reference is
+// zero.
+type percentOfReferenceTriggerFn struct {
+	pct      float64
+	fraction decimal.Decimal
+}
+
+// percentOfReferenceFactory expects the percentage as a bare number or as
+// {"pct": number}.
+func percentOfReferenceFactory(params interface{}) (TriggerFn, error) {
+	var p struct {
+		Pct float64 `json:"pct"`
+	}
+	if err := decodeParams("percentOfReference", params, &p); err != nil {
+		return nil, err
+	}
+	if _, err := nonNegativeFloat("percentOfReference", p.Pct); err != nil {
+		return nil, err
+	}
+	return percentOfReferenceTriggerFn{
+		pct:      p.Pct,
+		fraction: decimal.NewFromFloat(p.Pct).Div(decimal.NewFromInt(100)),
+	}, nil
+}
+
+// Triggering requires tc.Reference.
+func (p percentOfReferenceTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	if tc.Reference == nil {
+		return false, errors.New("percentOfReference requires a reference value")
+	}
+	return relativeDeviationAtLeast(*tc.Reference, new, p.fraction), nil
+}
+
+func (p percentOfReferenceTriggerFn) Factory() string         { return "percentOfReference" }
+func (p percentOfReferenceTriggerFn) Parameters() interface{} { return p.pct }
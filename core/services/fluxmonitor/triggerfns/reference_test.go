@@ -0,0 +1,53 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentOfReference(t *testing.T) {
+	tfn := mustScanOne(t, `{"percentOfReference": 2}`)
+	assert.Equal(t, float64(2), tfn.Parameters())
+
+	tests := []struct {
+		name              string
+		current, new, ref string
+		wantTriggered     bool
+	}{
+		{"within 2% of reference", "100", "101.9", "100", false},
+		{"2% above reference", "101", "102", "100", true},
+		{"2% below reference", "98.5", "98", "100", true},
+		{"large move which stays near reference", "90", "100.5", "100", false},
+		{"no move away from reference", "102", "102", "100", true},
+		{"zero reference", "0", "0.01", "0", true},
+		{"zero reference and new", "1", "0", "0", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ref := decimal.RequireFromString(test.ref)
+			fired, err := tfn.Triggering(context.Background(),
+				decimal.RequireFromString(test.current), decimal.RequireFromString(test.new),
+				triggerfns.TriggerContext{Reference: &ref})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestPercentOfReference_RequiresReference(t *testing.T) {
+	tfn := mustScanOne(t, `[{"type": "percentOfReference", "params": {"pct": 2}}]`)
+	_, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "percentOfReference requires a reference value")
+}
+
+func TestPercentOfReference_BadParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"percentOfReference": -1}`))
+	assert.Error(t, tfns.Scan(`{"percentOfReference": {"percent": 2}}`))
+}
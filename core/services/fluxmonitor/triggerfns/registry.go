@@ -0,0 +1,239 @@
+package triggerfns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// Factory builds a TriggerFn from the params given in a spec.
+type Factory interface {
+	New(params interface{}) (TriggerFn, error)
+}
+
+// FactoryFunc is a Factory which is a plain func.
+type FactoryFunc func(params interface{}) (TriggerFn, error)
+
+// New calls f.
+func (f FactoryFunc) New(params interface{}) (TriggerFn, error) { return f(params) }
+
+// DefaultParamser is implemented by factories which can be built without
+// params. DefaultParams returns the params used when a spec's are null or an
+// empty object, as in {"relativeThreshold": {}}. Factories which don't
+// implement it are given such params as they are, which most reject.
+type DefaultParamser interface {
+	DefaultParams() interface{}
+}
+
+// defaultParamsFactory is a built in FactoryFunc with default params.
+type defaultParamsFactory struct {
+	FactoryFunc
+	defaults interface{}
+}
+
+func (d defaultParamsFactory) DefaultParams() interface{} { return d.defaults }
+
+var (
+	triggerFnFactoriesMu sync.RWMutex
+	triggerFnFactories   = map[string]Factory{
+		"relativeThreshold":  defaultParamsFactory{FactoryFunc(relativeThresholdFactory), json.Number("0.005")},
+		"relativeBps":        FactoryFunc(relativeBpsFactory),
+		"relativeToNew":      FactoryFunc(relativeToNewFactory),
+		"signedRelative":     FactoryFunc(signedRelativeFactory),
+		"absoluteThreshold":  FactoryFunc(absoluteThresholdFactory),
+		"hysteresis":         FactoryFunc(hysteresisThresholdFactory),
+		"staleness":          FactoryFunc(stalenessThresholdFactory),
+		"increaseThreshold":  FactoryFunc(increaseThresholdFactory),
+		"decreaseThreshold":  FactoryFunc(decreaseThresholdFactory),
+		"relativeWithFloor":  FactoryFunc(relativeWithFloorFactory),
+		"maxOfAbsRel":        FactoryFunc(maxOfAbsRelFactory),
+		"band":               FactoryFunc(bandFactory),
+		"zscore":             FactoryFunc(zscoreFactory),
+		"ewmaThreshold":      FactoryFunc(ewmaThresholdFactory),
+		"adaptiveThreshold":  FactoryFunc(adaptiveFactory),
+		"maCrossover":        FactoryFunc(maCrossoverFactory),
+		"medianDeviation":    FactoryFunc(medianDeviationFactory),
+		"reentry":            FactoryFunc(reentryFactory),
+		"crossing":           FactoryFunc(crossingFactory),
+		"floor":              FactoryFunc(floorFactory),
+		"ceiling":            FactoryFunc(ceilingFactory),
+		"percentOfReference": FactoryFunc(percentOfReferenceFactory),
+		"logRatio":           FactoryFunc(logRatioFactory),
+		"always":             FactoryFunc(alwaysFactory),
+		"never":              FactoryFunc(neverFactory),
+	}
+)
+
+// RegisterTriggerFn makes factory available under name to Scan, alongside the
+// built in trigger functions. It returns an error if name is empty or already
+// taken, or if factory is nil. factory receives its params as decoded by
+// encoding/json, with numbers as float64s.
+func RegisterTriggerFn(name string, factory func(params interface{}) (TriggerFn, error)) error {
+	return RegisterTriggerFns(map[string]func(params interface{}) (TriggerFn, error){name: factory})
+}
+
+// RegisterTriggerFnFactory is RegisterTriggerFn for a Factory, which may
+// supply default params by implementing DefaultParamser.
+func RegisterTriggerFnFactory(name string, factory Factory) error {
+	triggerFnFactoriesMu.Lock()
+	defer triggerFnFactoriesMu.Unlock()
+	if err := checkRegistration(name, factory); err != nil {
+		return err
+	}
+	triggerFnFactories[name] = plainNumbersFactory{factory}
+	return nil
+}
+
+// RegisterTriggerFns registers each of factories under its name, as
+// RegisterTriggerFn does, for a plugin which provides several. Either all are
+// registered or, if any can't be, none are, and the error lists every name
+// which couldn't be.
+func RegisterTriggerFns(factories map[string]func(params interface{}) (TriggerFn, error)) error {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	triggerFnFactoriesMu.Lock()
+	defer triggerFnFactoriesMu.Unlock()
+	var merr error
+	for _, name := range names {
+		var factory Factory
+		if factories[name] != nil {
+			factory = FactoryFunc(factories[name])
+		}
+		merr = multierr.Append(merr, checkRegistration(name, factory))
+	}
+	if merr != nil {
+		return merr
+	}
+	for name, factory := range factories {
+		triggerFnFactories[name] = plainNumbersFactory{FactoryFunc(factory)}
+	}
+	return nil
+}
+
+// plainNumbersFactory passes factories registered from outside this package
+// their params with numbers as float64s.
+type plainNumbersFactory struct {
+	Factory
+}
+
+func (p plainNumbersFactory) New(params interface{}) (TriggerFn, error) {
+	return p.Factory.New(plainNumbers(params))
+}
+
+// checkRegistration returns an error if factory can't be registered under
+// name. triggerFnFactoriesMu must be held.
+func checkRegistration(name string, factory Factory) error {
+	if name == "" {
+		return errors.New("trigger function name must not be empty")
+	}
+	if factory == nil {
+		return errors.Errorf("trigger function %s has a nil factory", name)
+	}
+	if _, exists := triggerFnFactories[name]; exists {
+		return errors.Errorf("trigger function %s is already registered", name)
+	}
+	return nil
+}
+
+// RegisteredTriggerFns returns the names of every trigger function Scan can
+// build, in sorted order.
+func RegisteredTriggerFns() []string {
+	triggerFnFactoriesMu.RLock()
+	names := make([]string, 0, len(triggerFnFactories))
+	for name := range triggerFnFactories {
+		names = append(names, name)
+	}
+	triggerFnFactoriesMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// RegistrySnapshot records the trigger functions registered at some point, so
+// that they can later be restored with RestoreRegistry.
+type RegistrySnapshot struct {
+	factories map[string]Factory
+}
+
+// SnapshotRegistry captures the trigger functions currently registered.
+func SnapshotRegistry() RegistrySnapshot {
+	triggerFnFactoriesMu.RLock()
+	defer triggerFnFactoriesMu.RUnlock()
+	factories := make(map[string]Factory, len(triggerFnFactories))
+	for name, factory := range triggerFnFactories {
+		factories[name] = factory
+	}
+	return RegistrySnapshot{factories: factories}
+}
+
+// RestoreRegistry makes the trigger functions registered exactly those
+// captured in snap, removing any registered since. TriggerFns already built
+// are unaffected. A zero RegistrySnapshot is ignored.
+func RestoreRegistry(snap RegistrySnapshot) {
+	if snap.factories == nil {
+		return
+	}
+	triggerFnFactoriesMu.Lock()
+	defer triggerFnFactoriesMu.Unlock()
+	for name := range triggerFnFactories {
+		delete(triggerFnFactories, name)
+	}
+	for name, factory := range snap.factories {
+		triggerFnFactories[name] = factory
+	}
+}
+
+// makeTriggerFn builds the TriggerFn registered under name from params, named
+// if params carry a name.
+func makeTriggerFn(name string, params interface{}) (TriggerFn, error) {
+	triggerFnFactoriesMu.RLock()
+	factory, ok := triggerFnFactories[name]
+	triggerFnFactoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownTriggerFn, name)
+	}
+	label, params, err := splitName(name, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while constructing trigger function %s", name)
+	}
+	factoryParams := params
+	if defaults, ok := defaultParams(factory); ok && (params == nil || isEmptyObject(params)) {
+		factoryParams = defaults
+	}
+	triggerFn, err := factory.New(factoryParams)
+	if err != nil {
+		return nil, errors.Wrapf(asParamError(name, factoryParams, err), "while constructing trigger function %s", name)
+	}
+	if triggerFn == nil {
+		return nil, errors.Errorf("factory for trigger function %s returned no function", name)
+	}
+	if label != "" {
+		return meteredTriggerFn{TriggerFn: triggerFn, name: label, params: params.(map[string]interface{})}, nil
+	}
+	return triggerFn, nil
+}
+
+// isEmptyObject returns true if params is an object with no keys.
+func isEmptyObject(params interface{}) bool {
+	m, ok := params.(map[string]interface{})
+	return ok && len(m) == 0
+}
+
+// defaultParams returns factory's default params, if it has any.
+func defaultParams(factory Factory) (interface{}, bool) {
+	if p, ok := factory.(plainNumbersFactory); ok {
+		factory = p.Factory
+	}
+	d, ok := factory.(DefaultParamser)
+	if !ok {
+		return nil, false
+	}
+	return d.DefaultParams(), true
+}
@@ -0,0 +1,247 @@
+package triggerfns_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// squareDeviation fires when the squared difference reaches threshold.
+type squareDeviation struct {
+	threshold float64
+}
+
+func newSquareDeviation(params interface{}) (triggerfns.TriggerFn, error) {
+	threshold, ok := params.(float64)
+	if !ok {
+		return nil, errors.Errorf("squareDeviation requires a float parameter, got %v", params)
+	}
+	return squareDeviation{threshold: threshold}, nil
+}
+
+func (s squareDeviation) Triggering(_ context.Context, current, new decimal.Decimal, _ triggerfns.TriggerContext) (bool, error) {
+	diff := new.Sub(current)
+	return !diff.Mul(diff).LessThan(decimal.NewFromFloat(s.threshold)), nil
+}
+
+func (s squareDeviation) Factory() string         { return "squareDeviation" }
+func (s squareDeviation) Parameters() interface{} { return s.threshold }
+
+func TestRegisterTriggerFn(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"squareDeviation": 4}`))
+	require.Len(t, tfns, 1)
+
+	fired, err := tfns[0].Triggering(context.Background(), decimal.NewFromInt(10), decimal.NewFromInt(11), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+	fired, err = tfns[0].Triggering(context.Background(), decimal.NewFromInt(10), decimal.NewFromInt(12), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestRegisterTriggerFn_NameTaken(t *testing.T) {
+	err := triggerfns.RegisterTriggerFn("relativeThreshold", newSquareDeviation)
+	assert.EqualError(t, err, "trigger function relativeThreshold is already registered")
+
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+	assert.Error(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+}
+
+func TestRegisterTriggerFn_RejectsEmptyNameAndNilFactory(t *testing.T) {
+	assert.EqualError(t, triggerfns.RegisterTriggerFn("", newSquareDeviation),
+		"trigger function name must not be empty")
+	assert.EqualError(t, triggerfns.RegisterTriggerFn("squareDeviation", nil),
+		"trigger function squareDeviation has a nil factory")
+
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"squareDeviation": 4}`))
+}
+
+func TestRegisterTriggerFns(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFns(map[string]func(interface{}) (triggerfns.TriggerFn, error){
+		"squareDeviation": newSquareDeviation,
+		"oracleBacked": func(interface{}) (triggerfns.TriggerFn, error) {
+			return oracleBacked{make(chan bool)}, nil
+		},
+	}))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+	defer triggerfns.ExportedUnregisterTriggerFn("oracleBacked")
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"squareDeviation": 4, "oracleBacked": null}`))
+	assert.Len(t, tfns, 2)
+}
+
+func TestRegisterTriggerFns_CollisionRegistersNone(t *testing.T) {
+	before := triggerfns.RegisteredTriggerFns()
+
+	// relativeThreshold sorts between the two new names.
+	err := triggerfns.RegisterTriggerFns(map[string]func(interface{}) (triggerfns.TriggerFn, error){
+		"aSquareDeviation":  newSquareDeviation,
+		"relativeThreshold": newSquareDeviation,
+		"zSquareDeviation":  newSquareDeviation,
+		"nilFactory":        nil,
+	})
+	assert.EqualError(t, err, "trigger function nilFactory has a nil factory; "+
+		"trigger function relativeThreshold is already registered")
+	assert.Equal(t, before, triggerfns.RegisteredTriggerFns())
+
+	// relativeThreshold is still the built in.
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+	assert.Equal(t, "relativeThreshold(0.01)", tfns.String())
+}
+
+func TestRegisteredTriggerFns(t *testing.T) {
+	names := triggerfns.RegisteredTriggerFns()
+	assert.True(t, sort.StringsAreSorted(names), "%v", names)
+	for _, builtIn := range []string{"relativeThreshold", "absoluteThreshold", "staleness", "and", "or", "not", "cooldown"} {
+		assert.Contains(t, names, builtIn)
+	}
+	assert.NotContains(t, names, "squareDeviation")
+
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+	assert.Contains(t, triggerfns.RegisteredTriggerFns(), "squareDeviation")
+	assert.Len(t, triggerfns.RegisteredTriggerFns(), len(names)+1)
+}
+
+func TestSnapshotRegistry_Restore(t *testing.T) {
+	builtIns := triggerfns.RegisteredTriggerFns()
+	snap := triggerfns.SnapshotRegistry()
+
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"squareDeviation": 4}`))
+
+	triggerfns.RestoreRegistry(snap)
+	assert.Equal(t, builtIns, triggerfns.RegisteredTriggerFns())
+	assert.Error(t, tfns.Scan(`{"squareDeviation": 4}`))
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01, "cooldown": {"period": "5m", "triggerFn": {"type": "staleness", "params": 60}}}`))
+
+	// Restoring also brings back functions unregistered since the snapshot,
+	// and may be repeated.
+	triggerfns.ExportedUnregisterTriggerFn("relativeThreshold")
+	triggerfns.RestoreRegistry(snap)
+	triggerfns.RestoreRegistry(snap)
+	assert.Equal(t, builtIns, triggerfns.RegisteredTriggerFns())
+
+	triggerfns.RestoreRegistry(triggerfns.RegistrySnapshot{})
+	assert.Equal(t, builtIns, triggerfns.RegisteredTriggerFns())
+}
+
+// oracleBacked stands in for a trigger function which asks an external
+// service for its answer, and so has to honour cancellation.
+type oracleBacked struct {
+	answer chan bool
+}
+
+func (o oracleBacked) Triggering(ctx context.Context, _, _ decimal.Decimal, _ triggerfns.TriggerContext) (bool, error) {
+	select {
+	case fired := <-o.answer:
+		return fired, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+func (o oracleBacked) Factory() string         { return "oracleBacked" }
+func (o oracleBacked) Parameters() interface{} { return nil }
+
+func TestTriggering_CancelledContext(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFn("oracleBacked",
+		func(interface{}) (triggerfns.TriggerFn, error) { return oracleBacked{make(chan bool)}, nil }))
+	defer triggerfns.ExportedUnregisterTriggerFn("oracleBacked")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "oracleBacked", "params": null},
+		{"type": "and", "params": {"relativeThreshold": 0.01, "oracleBacked": null}},
+		{"type": "staleness", "params": 60}
+	]`))
+	for _, tfn := range tfns {
+		_, err := tfn.Triggering(ctx, decimal.NewFromInt(100), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+		assert.Equal(t, context.Canceled, err, tfn.Factory())
+	}
+}
+
+func TestDefaultParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"relativeThreshold": {}}`,
+		`[{"type": "relativeThreshold", "params": {}}]`,
+	} {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(spec), spec)
+		require.NoError(t, tfns.Validate())
+		assert.Equal(t, "relativeThreshold(0.005)", tfns.String())
+		value, err := tfns.Value()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version": 2, "functions": [{"type": "relativeThreshold", "params": 0.005}]}`, string(value.([]byte)))
+
+		fired, err := tfns.ShouldReport(context.Background(), decimal.NewFromInt(100), decimal.RequireFromString("100.5"),
+			triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.True(t, fired)
+		fired, err = tfns.ShouldReport(context.Background(), decimal.NewFromInt(100), decimal.RequireFromString("100.4"),
+			triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.False(t, fired)
+	}
+}
+
+func TestDefaultParams_OnlyWithoutParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	// Null params are defaulted as an empty object is.
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": null}`))
+	assert.Equal(t, "relativeThreshold(0.005)", tfns.String())
+	require.NoError(t, tfns.Scan(`[{"type": "relativeThreshold"}]`))
+	assert.Equal(t, "relativeThreshold(0.005)", tfns.String())
+	// Factories without a default still require their params.
+	assert.Error(t, tfns.Scan(`{"absoluteThreshold": {}}`))
+	assert.Error(t, tfns.Scan(`{"absoluteThreshold": null}`))
+	// Partial objects aren't defaulted.
+	assert.Error(t, tfns.Scan(`{"relativeThreshold": {"fireOnFirst": false}}`))
+}
+
+type defaultedFactory struct{}
+
+func (defaultedFactory) New(params interface{}) (triggerfns.TriggerFn, error) {
+	return triggerfns.ExportedAbsoluteThresholdFactory(params)
+}
+
+func (defaultedFactory) DefaultParams() interface{} { return 5.0 }
+
+func TestDefaultParams_RegisteredFactory(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFnFactory("defaulted", defaultedFactory{}))
+	defer triggerfns.ExportedUnregisterTriggerFn("defaulted")
+
+	tfn, err := triggerfns.ExportedMakeTriggerFn("defaulted", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, "absoluteThreshold(5)", triggerfns.TriggerFns{tfn}.String())
+	tfn, err = triggerfns.ExportedMakeTriggerFn("defaulted", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "absoluteThreshold(5)", triggerfns.TriggerFns{tfn}.String())
+	tfn, err = triggerfns.ExportedMakeTriggerFn("defaulted", 2.0)
+	require.NoError(t, err)
+	assert.Equal(t, "absoluteThreshold(2)", triggerfns.TriggerFns{tfn}.String())
+
+	assert.Error(t, triggerfns.RegisterTriggerFnFactory("defaulted", defaultedFactory{}))
+	assert.Error(t, triggerfns.RegisterTriggerFnFactory("", defaultedFactory{}))
+	assert.Error(t, triggerfns.RegisterTriggerFnFactory("undefaulted", nil))
+}
@@ -0,0 +1,71 @@
+package triggerfns
+
+import (
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxPow10 bounds the exponent gaps relativeBounds handles itself. Wider
+// gaps fall back to relativeDeviationAtLeast.
+const maxPow10 = 38
+
+var pow10 = func() [maxPow10 + 1]*big.Int {
+	var table [maxPow10 + 1]*big.Int
+	ten := big.NewInt(10)
+	table[0] = big.NewInt(1)
+	for i := 1; i <= maxPow10; i++ {
+		table[i] = new(big.Int).Mul(table[i-1], ten)
+	}
+	return table
+}()
+
+// relativeBounds answers relativeDeviationAtLeast for a fixed threshold with
+// far fewer allocations. Rather than building decimals for the deviation and
+// for threshold*|current|, it brings the coefficients of current, new and the
+// threshold to a common exponent and compares them as integers. It holds no
+// state beyond the threshold, so it may be shared freely. Its answers are
+// exactly those of relativeDeviationAtLeast.
+type relativeBounds struct {
+	threshold   decimal.Decimal
+	coefficient *big.Int // of threshold, never modified
+	exp         int32    // of threshold
+}
+
+func newRelativeBounds(threshold decimal.Decimal) relativeBounds {
+	return relativeBounds{
+		threshold:   threshold,
+		coefficient: threshold.Coefficient(),
+		exp:         threshold.Exponent(),
+	}
+}
+
+// atLeast returns true if |new-current| >= threshold*|current|. With current
+// = cc*10^ce, new = nc*10^ne and threshold = tc*10^te, that is
+// |nc*10^(ne-m) - cc*10^(ce-m)| >= tc*|cc|*10^(te+ce-m), where m is the least
+// of the exponents.
+func (r relativeBounds) atLeast(current, new decimal.Decimal) bool {
+	if current.Sign() == 0 {
+		return new.Sign() != 0 && !ZeroCurrentDeviation.LessThan(r.threshold)
+	}
+	ce, ne := int(current.Exponent()), int(new.Exponent())
+	me := int(r.exp) + ce
+	m := ce
+	if ne < m {
+		m = ne
+	}
+	if me < m {
+		m = me
+	}
+	if ce-m > maxPow10 || ne-m > maxPow10 || me-m > maxPow10 {
+		return relativeDeviationAtLeast(current, new, r.threshold)
+	}
+	cc := current.Coefficient()
+	var deviation, c, margin big.Int
+	deviation.Mul(new.Coefficient(), pow10[ne-m])
+	c.Mul(cc, pow10[ce-m])
+	deviation.Sub(&deviation, &c)
+	margin.Mul(r.coefficient, cc.Abs(cc))
+	c.Mul(&margin, pow10[me-m])
+	return deviation.CmpAbs(&c) >= 0
+}
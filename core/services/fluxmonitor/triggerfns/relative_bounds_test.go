@@ -0,0 +1,73 @@
+package triggerfns
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeBounds_MatchesRelativeDeviationAtLeast(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomDecimal := func() decimal.Decimal {
+		return decimal.New(rng.Int63n(2000000)-1000000, int32(rng.Intn(13)-9))
+	}
+	for _, threshold := range []string{"0", "0.005", "0.1", "0.12345678901234567891", "2"} {
+		threshold := decimal.RequireFromString(threshold)
+		bounds := newRelativeBounds(threshold)
+		for i := 0; i < 20000; i++ {
+			current := randomDecimal()
+			new := randomDecimal()
+			if i%3 == 0 {
+				// land on, or next to, a bound
+				new = current.Add(threshold.Mul(current.Abs())).Add(decimal.New(int64(rng.Intn(3)-1), new.Exponent()))
+			}
+			expected := relativeDeviationAtLeast(current, new, threshold)
+			assert.Equal(t, expected, bounds.atLeast(current, new), "%s to %s with threshold %s", current, new, threshold)
+		}
+	}
+}
+
+func TestRelativeBounds_WideExponentGap(t *testing.T) {
+	bounds := newRelativeBounds(decimal.RequireFromString("0.01"))
+	current := decimal.New(1, 60)
+	assert.False(t, bounds.atLeast(current, decimal.New(1, 60)))
+	assert.True(t, bounds.atLeast(current, decimal.New(1, -10)))
+	assert.True(t, bounds.atLeast(current, decimal.New(2, 60)))
+}
+
+func BenchmarkRelativeThreshold(b *testing.B) {
+	current, new := decimal.RequireFromString("1234.5678"), decimal.RequireFromString("1239.1")
+	ctx := context.Background()
+
+	b.Run("relativeDeviationAtLeast", func(b *testing.B) {
+		threshold := decimal.NewFromFloat(0.005)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			relativeDeviationAtLeast(current, new, threshold)
+		}
+	})
+	b.Run("relativeThreshold", func(b *testing.B) {
+		tfn, err := relativeThresholdFactory(0.005)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = tfn.Triggering(ctx, current, new, TriggerContext{})
+		}
+	})
+	b.Run("relativeThreshold/changingCurrent", func(b *testing.B) {
+		tfn, err := relativeThresholdFactory(0.005)
+		if err != nil {
+			b.Fatal(err)
+		}
+		currents := []decimal.Decimal{current, current.Add(decimal.New(1, -4)), current.Sub(decimal.New(3, -2))}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = tfn.Triggering(ctx, currents[i%len(currents)], new, TriggerContext{})
+		}
+	})
+}
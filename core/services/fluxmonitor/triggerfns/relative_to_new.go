@@ -0,0 +1,24 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// relativeToNewFactory returns a TriggerFn which fires when current differs
+// from new by at least the given fraction of new, rather than of current as
+// relativeThreshold does. A fall from 100 to 50 is then a deviation of 100%,
+// not 50%. If new is zero, it fires whenever current is nonzero, as
+// relativeThreshold does when current is zero.
+func relativeToNewFactory(params interface{}) (TriggerFn, error) {
+	t, parameter, err := exactNonNegative("relativeToNew", params)
+	if err != nil {
+		return nil, err
+	}
+	return thresholdTriggerFn{
+		factory:   "relativeToNew",
+		parameter: parameter,
+		threshold: t,
+		triggering: func(current, new decimal.Decimal) bool {
+			return relativeDeviationAtLeast(new, current, t)
+		},
+		measure: relativeToNewMeasure,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeToNew(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "relativeThreshold", "params": 0.6},
+		{"type": "relativeToNew", "params": 0.6}
+	]`))
+	relative := tfns.FilterByFactory("relativeThreshold")[0]
+	toNew := tfns.FilterByFactory("relativeToNew")[0]
+	assert.Equal(t, 0.6, toNew.Parameters())
+
+	tests := []struct {
+		name                 string
+		current, new         int64
+		wantRelative, wantTo bool
+	}{
+		{"unchanged", 100, 100, false, false},
+		// Halving is 50% of current, but 100% of new.
+		{"halved", 100, 50, false, true},
+		// Doubling is 100% of current, but 50% of new.
+		{"doubled", 100, 200, true, false},
+		{"to zero", 100, 0, true, true},
+		{"from zero", 0, 100, true, true},
+		{"zero to zero", 0, 0, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.NewFromInt(test.current), decimal.NewFromInt(test.new)
+			fired, err := relative.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantRelative, fired, "relativeThreshold")
+			fired, err = toNew.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTo, fired, "relativeToNew")
+		})
+	}
+}
+
+func TestRelativeToNew_Reason(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeToNew": 0.5}`))
+
+	result, err := triggerfns.TriggeringWithReason(context.Background(), tfns[0],
+		decimal.NewFromInt(100), decimal.NewFromInt(50), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, result.Fired)
+	assert.Equal(t, "relative deviation to new 100.00% >= 50.00%", result.Reason)
+
+	result, err = triggerfns.TriggeringWithReason(context.Background(), tfns[0],
+		decimal.NewFromInt(100), decimal.Zero, triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, result.Fired)
+	assert.Equal(t, "relative deviation to new from zero 100.00% >= 50.00%", result.Reason)
+}
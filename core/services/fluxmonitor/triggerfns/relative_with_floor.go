@@ -0,0 +1,65 @@
+package triggerfns
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// relativeWithFloorTriggerFn fires only when new differs from current both by
+// at least the relative threshold and by at least the absolute floor. The
+// floor keeps tiny prices, whose relative moves are mostly noise, from
+// triggering reports.
+//
+// Equivalently, it fires when the move reaches whichever of the two
+// thresholds is larger at current's price level, which maxOfAbsRel names.
+type relativeWithFloorTriggerFn struct {
+	factory                  string
+	relative, absolute       float64
+	relativeDec, absoluteDec decimal.Decimal
+}
+
+// relativeWithFloorParams are the params of relativeWithFloor.
+type relativeWithFloorParams struct {
+	Relative float64 `json:"relative"`
+	Absolute float64 `json:"absolute"`
+}
+
+// relativeWithFloorFactory expects params of the form
+// {"relative": 0.005, "absolute": 0.0001}.
+func relativeWithFloorFactory(params interface{}) (TriggerFn, error) {
+	return newRelativeWithFloor("relativeWithFloor", params)
+}
+
+// maxOfAbsRelFactory takes the same params as relativeWithFloorFactory.
+func maxOfAbsRelFactory(params interface{}) (TriggerFn, error) {
+	return newRelativeWithFloor("maxOfAbsRel", params)
+}
+
+func newRelativeWithFloor(factory string, params interface{}) (TriggerFn, error) {
+	var p relativeWithFloorParams
+	if err := decodeParams(factory, params, &p); err != nil {
+		return nil, err
+	}
+	if p.Relative < 0 || p.Absolute < 0 {
+		return nil, errors.Errorf("%s requires non-negative thresholds, got %+v", factory, p)
+	}
+	return relativeWithFloorTriggerFn{
+		factory:     factory,
+		relative:    p.Relative,
+		absolute:    p.Absolute,
+		relativeDec: decimal.NewFromFloat(p.Relative),
+		absoluteDec: decimal.NewFromFloat(p.Absolute),
+	}, nil
+}
+
+func (r relativeWithFloorTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	return relativeDeviationAtLeast(current, new, r.relativeDec) &&
+		!AbsoluteDeviation(current, new).LessThan(r.absoluteDec), nil
+}
+
+func (r relativeWithFloorTriggerFn) Factory() string { return r.factory }
+
+func (r relativeWithFloorTriggerFn) Parameters() interface{} {
+	return map[string]interface{}{"relative": r.relative, "absolute": r.absolute}
+}
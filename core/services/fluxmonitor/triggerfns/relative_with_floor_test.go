@@ -0,0 +1,120 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeWithFloor_Triggering(t *testing.T) {
+	tfn := mustScanOne(t, `{"relativeWithFloor": {"relative": 0.01, "absolute": 0.5}}`)
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"both exceeded", "100", "101", true},
+		{"relative passes, floor fails", "0.0001", "0.0002", false},
+		{"floor passes, relative fails", "1000", "1001", false},
+		{"neither exceeded", "100", "100.1", false},
+		{"zero current, floor passes", "0", "1", true},
+		{"zero current, floor fails", "0", "0.1", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current), decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestRelativeWithFloor_ValueScanRoundTrip(t *testing.T) {
+	original := triggerfns.TriggerFns{
+		mustScanOne(t, `{"relativeWithFloor": {"relative": 0.005, "absolute": 0.0001}}`),
+	}
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t,
+		`{"version": 2, "functions": [{"type": "relativeWithFloor", "params": {"relative": 0.005, "absolute": 0.0001}}]}`,
+		string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, original.Equals(scanned))
+}
+
+func TestRelativeWithFloor_BadParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"not an object", `{"relativeWithFloor": 0.01}`},
+		{"missing absolute", `{"relativeWithFloor": {"relative": 0.01}}`},
+		{"missing relative", `{"relativeWithFloor": {"absolute": 0.01}}`},
+		{"negative absolute", `{"relativeWithFloor": {"relative": 0.01, "absolute": -1}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.params))
+		})
+	}
+}
+
+func TestMaxOfAbsRel(t *testing.T) {
+	// 0.5% or $0.01, whichever is larger at current's price level.
+	tfn := mustScanOne(t, `{"maxOfAbsRel": {"relative": 0.005, "absolute": 0.01}}`)
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		// At $1, 0.5% is only $0.005, so the $0.01 absolute threshold governs.
+		{"small price, relative alone is not enough", "1", "1.006", false},
+		{"small price, absolute reached", "1", "1.01", true},
+		// At $1000, 0.5% is $5, so the relative threshold governs.
+		{"large price, absolute alone is not enough", "1000", "1000.5", false},
+		{"large price, relative reached", "1000", "1005", true},
+		{"large price, relative reached downwards", "1000", "995", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current),
+				decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestMaxOfAbsRel_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"maxOfAbsRel": {"relative": 0.005, "absolute": 0.01}}`))
+	require.NoError(t, tfns.Validate())
+	assert.Equal(t, "maxOfAbsRel", tfns[0].Factory())
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "maxOfAbsRel", "params": {"relative": 0.005, "absolute": 0.01}}]}`, string(value.([]byte)))
+
+	schema, err := triggerfns.TriggerFnSchema("maxOfAbsRel")
+	require.NoError(t, err)
+	assert.Equal(t, schema, tfns[0].(triggerfns.SchemaProvider).ParamSchema())
+
+	for _, bad := range []string{
+		`{"maxOfAbsRel": {"relative": 0.005}}`,
+		`{"maxOfAbsRel": {"relative": -0.005, "absolute": 0.01}}`,
+	} {
+		err := tfns.Scan(bad)
+		require.Error(t, err, bad)
+		assert.Contains(t, err.Error(), "maxOfAbsRel requires")
+	}
+}
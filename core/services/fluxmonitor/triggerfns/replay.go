@@ -0,0 +1,130 @@
+package triggerfns
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ReplayStats summarizes how a set of trigger functions behaved over a
+// replayed series of answers.
+type ReplayStats struct {
+	// Ticks is the number of answers evaluated, which excludes the first,
+	// taken as already reported.
+	Ticks int
+	// Fires is the number of ticks which would have been reported.
+	Fires int
+	// Suppressed is the number of ticks which would not.
+	Suppressed int
+	// Gaps holds the time between each report and the one before it,
+	// starting from the first answer.
+	Gaps []time.Duration
+}
+
+// MaxGap returns the longest of s.Gaps, or zero if there were no reports.
+func (s ReplayStats) MaxGap() time.Duration {
+	var max time.Duration
+	for _, gap := range s.Gaps {
+		if gap > max {
+			max = gap
+		}
+	}
+	return max
+}
+
+// ReplayCSV evaluates f against historical answers, to show how often it
+// would have reported, e.g. before changing a threshold. r holds CSV rows of
+// timestamp,answer in time order, where the timestamp is RFC 3339 or Unix
+// seconds; a first row whose answer isn't a number is taken as a header. The
+// first answer is taken as reported, and each later one is passed to
+// ShouldReport against the last answer reported, with the clock reading the
+// row's timestamp. f is cloned first, so its own state is unaffected.
+func ReplayCSV(f TriggerFns, r io.Reader) (ReplayStats, error) {
+	f = f.Clone()
+	clock := &replayClock{}
+	f.SetClock(clock)
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var stats ReplayStats
+	var current decimal.Decimal
+	var lastReportedAt time.Time
+	var roundID uint32
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return stats, errors.Wrap(err, "while reading replay CSV")
+		}
+		answer, err := decimal.NewFromString(strings.TrimSpace(record[1]))
+		if err != nil {
+			if row == 1 {
+				continue
+			}
+			return stats, errors.Errorf("replay CSV row %d: invalid answer %q", row, record[1])
+		}
+		at, err := parseReplayTime(strings.TrimSpace(record[0]))
+		if err != nil {
+			return stats, errors.Wrapf(err, "replay CSV row %d", row)
+		}
+		if !lastReportedAt.IsZero() && at.Before(clock.now) {
+			return stats, errors.Errorf("replay CSV row %d: %s is before the previous row", row, record[0])
+		}
+		clock.now = at
+		if lastReportedAt.IsZero() {
+			current, lastReportedAt, roundID = answer, at, 1
+			continue
+		}
+
+		stats.Ticks++
+		fired, err := f.ShouldReport(context.Background(), current, answer,
+			TriggerContext{LastReportedAt: lastReportedAt, RoundID: roundID + 1})
+		if err != nil {
+			return stats, errors.Wrapf(err, "replay CSV row %d", row)
+		}
+		if !fired {
+			stats.Suppressed++
+			continue
+		}
+		stats.Fires++
+		stats.Gaps = append(stats.Gaps, at.Sub(lastReportedAt))
+		current, lastReportedAt = answer, at
+		roundID++
+	}
+	return stats, nil
+}
+
+// parseReplayTime reads an RFC 3339 time or a number of Unix seconds.
+func parseReplayTime(txt string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(txt, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, txt)
+	if err != nil {
+		return time.Time{}, errors.Errorf("timestamp must be RFC 3339 or Unix seconds, got %q", txt)
+	}
+	return t, nil
+}
+
+// replayClock reads the timestamp of the row being replayed. After fires
+// immediately, as a replay doesn't wait.
+type replayClock struct {
+	now time.Time
+}
+
+func (c *replayClock) Now() time.Time { return c.now }
+
+func (c *replayClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
@@ -0,0 +1,84 @@
+package triggerfns_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replayPrices = `timestamp,answer
+2020-06-01T00:00:00Z,100
+2020-06-01T00:01:00Z,100.5
+2020-06-01T00:02:00Z,101
+2020-06-01T00:03:00Z,101.5
+2020-06-01T00:04:00Z,99
+2020-06-01T00:05:00Z,99.5
+2020-06-01T01:10:00Z,99.5
+`
+
+func TestReplayCSV(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01, "staleness": 3600}`))
+
+	stats, err := triggerfns.ReplayCSV(tfns, strings.NewReader(replayPrices))
+	require.NoError(t, err)
+	// 101 is 1% from 100, and 99 is 2% from 101. The last answer hasn't
+	// moved, but comes over an hour after the last report.
+	assert.Equal(t, triggerfns.ReplayStats{
+		Ticks:      6,
+		Fires:      3,
+		Suppressed: 3,
+		Gaps:       []time.Duration{2 * time.Minute, 2 * time.Minute, 66 * time.Minute},
+	}, stats)
+	assert.Equal(t, 66*time.Minute, stats.MaxGap())
+
+	// A tighter threshold reports more often.
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.005}`))
+	stats, err = triggerfns.ReplayCSV(tfns, strings.NewReader(replayPrices))
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.Fires)
+}
+
+func TestReplayCSV_UnixSecondsWithoutHeader(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"absoluteThreshold": 1}`))
+
+	stats, err := triggerfns.ReplayCSV(tfns, strings.NewReader("1591000000,10\n1591000060,10.5\n1591000120,11\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Ticks)
+	assert.Equal(t, 1, stats.Fires)
+	assert.Equal(t, []time.Duration{2 * time.Minute}, stats.Gaps)
+}
+
+func TestReplayCSV_LeavesStateAlone(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"debounce": {"count": 2, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`))
+
+	_, err := triggerfns.ReplayCSV(tfns, strings.NewReader("0,100\n60,110\n"))
+	require.NoError(t, err)
+	// Had the replay counted against tfns itself, this would be its second
+	// consecutive deviation.
+	assert.Equal(t, []bool{false}, debounceTicks(t, tfns[0], 110))
+}
+
+func TestReplayCSV_BadRows(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.01}`))
+
+	for _, test := range []struct {
+		csv, err string
+	}{
+		{"0,100\n60,abc\n", `replay CSV row 2: invalid answer "abc"`},
+		{"0,100\nyesterday,101\n", `replay CSV row 2: timestamp must be RFC 3339 or Unix seconds, got "yesterday"`},
+		{"60,100\n0,101\n", "replay CSV row 2: 0 is before the previous row"},
+		{"0,100,1\n", "while reading replay CSV: record on line 1: wrong number of fields"},
+	} {
+		_, err := triggerfns.ReplayCSV(tfns, strings.NewReader(test.csv))
+		assert.EqualError(t, err, test.err, test.csv)
+	}
+}
@@ -0,0 +1,63 @@
+package triggerfns
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["requireReference"] = FactoryFunc(requireReferenceFactory)
+}
+
+// requireReferenceTriggerFn fires when its inner function does, but only
+// while there is a live reference value to check answers against: never when
+// tc.Reference is nil or tc.ReferenceStale is set. The inner function is
+// evaluated either way, so that a stateful one sees every answer.
+type requireReferenceTriggerFn struct {
+	inner TriggerFn
+}
+
+// requireReferenceFactory expects the spec of the inner function, e.g.
+// {"type": "relativeThreshold", "params": 0.01}.
+func requireReferenceFactory(params interface{}) (TriggerFn, error) {
+	var p triggerFnJSON
+	if err := decodeParams("requireReference", params, &p); err != nil {
+		return nil, err
+	}
+	inner, err := makeTriggerFn(p.Type, p.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing requireReference's inner trigger function")
+	}
+	return requireReferenceTriggerFn{inner: inner}, nil
+}
+
+func (r requireReferenceTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := r.inner.Triggering(ctx, current, new, tc)
+	if err != nil {
+		return false, err
+	}
+	return fired && tc.Reference != nil && !tc.ReferenceStale, nil
+}
+
+func (r requireReferenceTriggerFn) Clone() TriggerFn {
+	return requireReferenceTriggerFn{inner: cloneTriggerFn(r.inner)}
+}
+
+func (r requireReferenceTriggerFn) Parameters() interface{} {
+	return triggerFnJSON{Type: r.inner.Factory(), Params: r.inner.Parameters()}
+}
+
+func (r requireReferenceTriggerFn) SetClock(clock utils.AfterNower) {
+	TriggerFns{r.inner}.SetClock(clock)
+}
+func (r requireReferenceTriggerFn) Reset()              { TriggerFns{r.inner}.ResetAll() }
+func (r requireReferenceTriggerFn) stateful() bool      { return isStateful(r.inner) }
+func (r requireReferenceTriggerFn) Factory() string     { return "requireReference" }
+func (r requireReferenceTriggerFn) ParamSchema() string { return triggerFnSchemas["requireReference"] }
+func (r requireReferenceTriggerFn) String() string {
+	return "requireReference(" + triggerFnString(r.inner) + ")"
+}
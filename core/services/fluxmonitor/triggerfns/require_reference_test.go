@@ -0,0 +1,58 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireReference(t *testing.T) {
+	tfn := mustScanOne(t, `{"requireReference": {"type": "relativeThreshold", "params": 0.01}}`)
+	ref := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name          string
+		new           string
+		tc            triggerfns.TriggerContext
+		wantTriggered bool
+	}{
+		{"live reference", "110", triggerfns.TriggerContext{Reference: &ref}, true},
+		{"stale reference", "110", triggerfns.TriggerContext{Reference: &ref, ReferenceStale: true}, false},
+		{"no reference", "110", triggerfns.TriggerContext{}, false},
+		{"inner does not fire", "100.5", triggerfns.TriggerContext{Reference: &ref}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(100),
+				decimal.RequireFromString(test.new), test.tc)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestRequireReference_ValueScanRoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[{"type": "requireReference", "params": {"type": "band", "params": {"upPct": 1, "downPct": 2}}}]`))
+	require.NoError(t, tfns.Validate())
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "requireReference", "params": {"type": "band", "params": {"upPct": 1, "downPct": 2}}}]}`,
+		string(value.([]byte)))
+}
+
+func TestRequireReference_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"requireReference": 0.01}`,
+		`{"requireReference": {"type": "frobnicate", "params": 1}}`,
+		`{"requireReference": {"type": "relativeThreshold", "params": -1}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
@@ -0,0 +1,62 @@
+package triggerfns
+
+import "time"
+
+// Resettable is implemented by trigger functions which accumulate state as
+// they are evaluated. Reset returns the function to its state when newly
+// constructed, keeping its parameters and clock.
+type Resettable interface {
+	Reset()
+}
+
+// ResetAll resets every function in f which implements Resettable, for
+// instance when a feed is reconfigured. Stateless functions are untouched.
+func (f TriggerFns) ResetAll() {
+	for _, tfn := range f {
+		if r, ok := tfn.(Resettable); ok {
+			r.Reset()
+		}
+	}
+}
+
+func (h *hysteresisTriggerFn) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.armed, h.hasReference = true, false
+}
+
+func (e *ewmaTriggerFn) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.seeded, e.ewma = false, 0
+}
+
+func (z *zscoreTriggerFn) Reset() {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.window, z.next = nil, 0
+}
+
+func (a *adaptiveTriggerFn) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.last, a.returns, a.next = 0, nil, 0
+}
+
+// Reset forgets when c last fired, and resets its inner function.
+func (c *cooldownTriggerFn) Reset() {
+	c.mu.Lock()
+	c.lastFired = time.Time{}
+	c.mu.Unlock()
+	TriggerFns{c.inner}.ResetAll()
+}
+
+// The wrappers and composites hold no state of their own, but pass Reset on
+// to their inner functions.
+
+func (c compositeTriggerFn) Reset()        { c.fns.ResetAll() }
+func (q quorumTriggerFn) Reset()           { q.fns.ResetAll() }
+func (n notTriggerFn) Reset()              { TriggerFns{n.inner}.ResetAll() }
+func (s submissionBoundsTriggerFn) Reset() { TriggerFns{s.inner}.ResetAll() }
+func (m minBlockGapTriggerFn) Reset()      { TriggerFns{m.inner}.ResetAll() }
+func (m meteredTriggerFn) Reset()          { TriggerFns{m.TriggerFn}.ResetAll() }
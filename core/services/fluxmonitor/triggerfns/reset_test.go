@@ -0,0 +1,51 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHysteresis_Reset(t *testing.T) {
+	spec := `{"hysteresis": {"upper": 0.02, "lower": 0.01}}`
+	answers := []float64{101, 102, 103, 101.5, 100.5, 103}
+	expected := pollSequence(t, mustScanOne(t, spec), 100, answers)
+
+	hysteresis := mustScanOne(t, spec)
+	// Disarm it, with a reference of 102.
+	require.Equal(t, []bool{true}, pollSequence(t, hysteresis, 100, []float64{102}))
+
+	require.Implements(t, (*triggerfns.Resettable)(nil), hysteresis)
+	hysteresis.(triggerfns.Resettable).Reset()
+	assert.Equal(t, expected, pollSequence(t, hysteresis, 100, answers))
+}
+
+func TestTriggerFns_ResetAll(t *testing.T) {
+	spec := `{
+		"ewmaThreshold": {"alpha": 0.5, "threshold": 0.01},
+		"zscore": {"windowSize": 3, "sigma": 1},
+		"not": {"type": "hysteresis", "params": {"upper": 0.02, "lower": 0.01}},
+		"or": {"adaptiveThreshold": {"base": 0.005, "multiplier": 2, "windowSize": 3}, "never": null},
+		"relativeThreshold": 0.01
+	}`
+	answers := []float64{100, 103, 99, 104, 100.5, 98, 102}
+
+	var fresh, used triggerfns.TriggerFns
+	require.NoError(t, fresh.Scan(spec))
+	require.NoError(t, used.Scan(spec))
+	used, err := used.WithMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	for _, tfn := range used {
+		pollSequence(t, tfn, 100, []float64{110, 90, 120, 80})
+	}
+	used.ResetAll()
+	for i := range fresh {
+		assert.Equal(t, pollSequence(t, fresh[i], 100, answers), pollSequence(t, used[i], 100, answers),
+			fresh[i].Factory())
+	}
+}
@@ -0,0 +1,74 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["scaled"] = FactoryFunc(scaledFactory)
+}
+
+// scaledParams are the params of scaled, e.g.
+// {"decimals": 8, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type scaledParams struct {
+	Decimals  uint8         `json:"decimals"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// scaledTriggerFn compares new with the answer held on chain, which is
+// new's units scaled up by 10^decimals, as aggregators store answers as
+// integers. It passes that answer, scaled back down, to its inner function
+// as current, so that the inner function compares like with like.
+type scaledTriggerFn struct {
+	decimals uint8
+	inner    TriggerFn
+}
+
+func scaledFactory(params interface{}) (TriggerFn, error) {
+	var p scaledParams
+	if err := decodeParams("scaled", params, &p); err != nil {
+		return nil, err
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing scaled's inner trigger function")
+	}
+	return scaledTriggerFn{decimals: p.Decimals, inner: inner}, nil
+}
+
+// Triggering ignores current in favour of tc.OnchainValue where that is
+// known. A zero OnchainValue is taken as unknown, as in relativeThreshold's
+// useOnchain, and current is passed on unchanged.
+func (s scaledTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	if !tc.OnchainValue.IsZero() {
+		current = tc.OnchainValue.Shift(-int32(s.decimals))
+	}
+	return s.inner.Triggering(ctx, current, new, tc)
+}
+
+// Clone returns a scaled around a clone of s's inner function.
+func (s scaledTriggerFn) Clone() TriggerFn {
+	return scaledTriggerFn{decimals: s.decimals, inner: cloneTriggerFn(s.inner)}
+}
+
+func (s scaledTriggerFn) Parameters() interface{} {
+	return scaledParams{
+		Decimals:  s.decimals,
+		TriggerFn: triggerFnJSON{Type: s.inner.Factory(), Params: s.inner.Parameters()},
+	}
+}
+
+func (s scaledTriggerFn) SetClock(clock utils.AfterNower) { TriggerFns{s.inner}.SetClock(clock) }
+func (s scaledTriggerFn) Reset()                          { TriggerFns{s.inner}.ResetAll() }
+func (s scaledTriggerFn) stateful() bool                  { return isStateful(s.inner) }
+func (s scaledTriggerFn) Factory() string                 { return "scaled" }
+func (s scaledTriggerFn) ParamSchema() string             { return triggerFnSchemas["scaled"] }
+func (s scaledTriggerFn) String() string {
+	return fmt.Sprintf("scaled(%d, %s)", s.decimals, triggerFnString(s.inner))
+}
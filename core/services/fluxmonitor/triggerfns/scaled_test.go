@@ -0,0 +1,93 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaled(t *testing.T) {
+	tfn := mustScanOne(t, `{"scaled": {"decimals": 8, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`)
+
+	tests := []struct {
+		name          string
+		onchain       string
+		new           string
+		wantTriggered bool
+	}{
+		{"unchanged", "123456000000", "1234.56", false},
+		{"within threshold", "123456000000", "1240", false},
+		{"beyond threshold", "123456000000", "1250", true},
+		{"fractional on-chain answer", "1", "0.00000001", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// current is ignored in favour of the on-chain answer.
+			fired, err := tfn.Triggering(context.Background(), decimal.NewFromInt(1), decimal.RequireFromString(test.new),
+				triggerfns.TriggerContext{OnchainValue: decimal.RequireFromString(test.onchain)})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestScaled_Unscales(t *testing.T) {
+	// An absoluteThreshold of 0.5 only fires if the on-chain answer is
+	// unscaled before comparison: scaled, it is 10^8 times too large.
+	tfn := mustScanOne(t, `{"scaled": {"decimals": 8, "triggerFn": {"type": "absoluteThreshold", "params": 0.5}}}`)
+	tc := triggerfns.TriggerContext{OnchainValue: decimal.NewFromInt(10000000000)} // 100
+	fired, err := tfn.Triggering(context.Background(), decimal.Zero, decimal.RequireFromString("100.4"), tc)
+	require.NoError(t, err)
+	assert.False(t, fired)
+	fired, err = tfn.Triggering(context.Background(), decimal.Zero, decimal.RequireFromString("100.5"), tc)
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestScaled_MissingOnchainValue(t *testing.T) {
+	// Without an on-chain answer, current is used as is, rather than taken
+	// as zero, from which every answer would fire.
+	tfn := mustScanOne(t, `{"scaled": {"decimals": 8, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`)
+	fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString("1234.56"), decimal.RequireFromString("1240"),
+		triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+	fired, err = tfn.Triggering(context.Background(), decimal.RequireFromString("1234.56"), decimal.RequireFromString("1250"),
+		triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired)
+}
+
+func TestScaled_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"scaled": {"decimals": 8, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`))
+	require.NoError(t, tfns.Validate())
+	assert.Equal(t, "scaled(8, relativeThreshold(0.01))", tfns.String())
+	params, err := json.Marshal(tfns[0].Parameters())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"decimals": 8, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}`, string(params))
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	var rescanned triggerfns.TriggerFns
+	require.NoError(t, rescanned.Scan(value))
+	assert.True(t, tfns.Equals(rescanned), "got %s", rescanned)
+}
+
+func TestScaled_BadParams(t *testing.T) {
+	for _, spec := range []string{
+		`{"scaled": {"decimals": -1, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+		`{"scaled": {"decimals": 256, "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+		`{"scaled": {"decimals": 8}}`,
+		`{"scaled": {"decimals": 8, "triggerFn": {"type": "frobnicate", "params": 1}}}`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(spec), spec)
+	}
+}
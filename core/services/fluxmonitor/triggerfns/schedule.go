@@ -0,0 +1,202 @@
+package triggerfns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["schedule"] = FactoryFunc(scheduleFactory)
+}
+
+// scheduleParams are the params of schedule, e.g.
+// {"windows": [{"start": "13:30", "end": "20:00", "days": ["Mon", "Tue", "Wed", "Thu", "Fri"]}],
+// "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+type scheduleParams struct {
+	Windows   []clockWindow `json:"windows"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// clockWindow is a range of UTC times of day, from start up to but not
+// including end, on the given days of the week, or every day if none are
+// given. A window whose end is before its start runs past midnight, and its
+// days are those it starts on.
+type clockWindow struct {
+	Start timeOfDay `json:"start"`
+	End   timeOfDay `json:"end"`
+	Days  []weekday `json:"days,omitempty"`
+}
+
+// contains returns true if t falls within w.
+func (w clockWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	sinceMidnight := timeOfDay(t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)))
+	if w.Start < w.End {
+		return sinceMidnight >= w.Start && sinceMidnight < w.End && w.on(t.Weekday())
+	}
+	return sinceMidnight >= w.Start && w.on(t.Weekday()) ||
+		sinceMidnight < w.End && w.on((t.Weekday()+6)%7)
+}
+
+// on returns true if w starts on day.
+func (w clockWindow) on(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (w clockWindow) String() string {
+	s := fmt.Sprintf("%s-%s", w.Start, w.End)
+	if len(w.Days) > 0 {
+		days := make([]string, len(w.Days))
+		for i, d := range w.Days {
+			days[i] = d.String()
+		}
+		s += " " + strings.Join(days, ",")
+	}
+	return s
+}
+
+// timeOfDay is a time since midnight, which is written in JSON in the form
+// "15:04". It may be "24:00", for a window which ends at midnight.
+type timeOfDay time.Duration
+
+func (t timeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", time.Duration(t)/time.Hour, time.Duration(t)%time.Hour/time.Minute)
+}
+
+func (t timeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *timeOfDay) UnmarshalJSON(input []byte) error {
+	var txt string
+	if err := json.Unmarshal(input, &txt); err != nil {
+		return err
+	}
+	var hours, minutes int
+	if n, err := fmt.Sscanf(txt, "%2d:%2d", &hours, &minutes); err != nil || n != 2 || len(txt) != 5 ||
+		hours < 0 || minutes < 0 || minutes > 59 || hours > 24 || hours == 24 && minutes != 0 {
+		return fmt.Errorf("time of day must be in the form 15:04, got %q", txt)
+	}
+	*t = timeOfDay(time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute)
+	return nil
+}
+
+// weekday is a time.Weekday, which is written in JSON in the form "Mon".
+type weekday time.Weekday
+
+func (d weekday) String() string { return time.Weekday(d).String()[:3] }
+
+func (d weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *weekday) UnmarshalJSON(input []byte) error {
+	var txt string
+	if err := json.Unmarshal(input, &txt); err != nil {
+		return err
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if weekday(day).String() == txt {
+			*d = weekday(day)
+			return nil
+		}
+	}
+	return fmt.Errorf("day must be one of Sun, Mon, Tue, Wed, Thu, Fri or Sat, got %q", txt)
+}
+
+// scheduleTriggerFn fires when its inner function does, but only at times
+// within one of its windows, as read from its clock. The inner function is
+// evaluated either way, so that a stateful one sees every answer.
+type scheduleTriggerFn struct {
+	windows []clockWindow
+	inner   TriggerFn
+
+	mu    sync.Mutex
+	clock utils.AfterNower
+}
+
+func scheduleFactory(params interface{}) (TriggerFn, error) {
+	var p scheduleParams
+	if err := decodeParams("schedule", params, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Windows) == 0 {
+		return nil, errors.New("schedule requires at least one window")
+	}
+	for i, w := range p.Windows {
+		if w.Start == w.End {
+			return nil, errors.Errorf("schedule window %d is empty, starting and ending at %s", i, w.Start)
+		}
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing schedule's inner trigger function")
+	}
+	return &scheduleTriggerFn{windows: p.Windows, inner: inner, clock: utils.Clock{}}, nil
+}
+
+func (s *scheduleTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := s.inner.Triggering(ctx, current, new, tc)
+	if err != nil || !fired {
+		return false, err
+	}
+	s.mu.Lock()
+	now := s.clock.Now()
+	s.mu.Unlock()
+	for _, w := range s.windows {
+		if w.contains(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SetClock sets the clock s reads the time of day from, and passes it on to
+// the inner function.
+func (s *scheduleTriggerFn) SetClock(clock utils.AfterNower) {
+	s.mu.Lock()
+	s.clock = clock
+	s.mu.Unlock()
+	TriggerFns{s.inner}.SetClock(clock)
+}
+
+// Clone returns a schedule around a clone of s's inner function.
+func (s *scheduleTriggerFn) Clone() TriggerFn {
+	return &scheduleTriggerFn{windows: s.windows, inner: cloneTriggerFn(s.inner), clock: s.clock}
+}
+
+func (s *scheduleTriggerFn) Parameters() interface{} {
+	return scheduleParams{
+		Windows:   s.windows,
+		TriggerFn: triggerFnJSON{Type: s.inner.Factory(), Params: s.inner.Parameters()},
+	}
+}
+
+func (s *scheduleTriggerFn) Reset()              { TriggerFns{s.inner}.ResetAll() }
+func (s *scheduleTriggerFn) stateful() bool      { return isStateful(s.inner) }
+func (s *scheduleTriggerFn) Factory() string     { return "schedule" }
+func (s *scheduleTriggerFn) ParamSchema() string { return triggerFnSchemas["schedule"] }
+func (s *scheduleTriggerFn) String() string {
+	windows := make([]string, len(s.windows))
+	for i, w := range s.windows {
+		windows[i] = w.String()
+	}
+	return fmt.Sprintf("schedule(%s, %s)", strings.Join(windows, "; "), triggerFnString(s.inner))
+}
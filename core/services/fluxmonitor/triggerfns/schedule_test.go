@@ -0,0 +1,125 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const marketHoursSpec = `{"schedule": {
+	"windows": [{"start": "13:30", "end": "20:00", "days": ["Mon", "Tue", "Wed", "Thu", "Fri"]}],
+	"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+}}`
+
+// pollAtTimes evaluates tfns[0] on a move its inner function always fires on,
+// with the clock reading each of the given times.
+func pollAtTimes(t *testing.T, tfns triggerfns.TriggerFns, times ...string) []bool {
+	t.Helper()
+	clock := new(mocks.AfterNower)
+	tfns.SetClock(clock)
+	fired := make([]bool, len(times))
+	for i, txt := range times {
+		now, err := time.Parse(time.RFC3339, txt)
+		require.NoError(t, err)
+		clock.On("Now").Return(now).Once()
+		fired[i], err = tfns[0].Triggering(context.Background(),
+			decimal.NewFromInt(100), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+	}
+	clock.AssertExpectations(t)
+	return fired
+}
+
+func TestSchedule_MarketHours(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(marketHoursSpec))
+
+	// 2020-06-01 was a Monday.
+	fired := pollAtTimes(t, tfns,
+		"2020-06-01T13:29:59Z",
+		"2020-06-01T13:30:00Z",
+		"2020-06-01T19:59:59Z",
+		"2020-06-01T20:00:00Z",
+		// Times are read in UTC, whatever the clock's zone.
+		"2020-06-01T10:00:00-04:00",
+		"2020-06-01T18:00:00-04:00",
+		// Weekends are outside the window.
+		"2020-06-06T15:00:00Z",
+		"2020-06-05T15:00:00Z",
+	)
+	assert.Equal(t, []bool{false, true, true, false, true, false, false, true}, fired)
+}
+
+func TestSchedule_OvernightWindow(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"schedule": {
+		"windows": [{"start": "22:00", "end": "02:00", "days": ["Fri"]}, {"start": "12:00", "end": "24:00"}],
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}`))
+
+	fired := pollAtTimes(t, tfns,
+		// The first window starts on Friday, and runs into Saturday.
+		"2020-06-05T23:00:00Z",
+		"2020-06-06T01:59:59Z",
+		"2020-06-06T02:00:00Z",
+		// It doesn't start on Thursday.
+		"2020-06-05T01:00:00Z",
+		// The second runs to midnight every day.
+		"2020-06-03T11:59:59Z",
+		"2020-06-03T23:59:59Z",
+		"2020-06-04T00:00:00Z",
+	)
+	assert.Equal(t, []bool{true, true, false, false, false, true, false}, fired)
+}
+
+func TestSchedule_DoesNotFireWithoutInner(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(marketHoursSpec))
+
+	// The inner function doesn't fire, so the clock isn't consulted.
+	tfns.SetClock(new(mocks.AfterNower))
+	fired, err := tfns[0].Triggering(context.Background(),
+		decimal.NewFromInt(100), decimal.NewFromInt(100), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+}
+
+func TestSchedule_Parameters(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(marketHoursSpec))
+	assert.Equal(t, "schedule(13:30-20:00 Mon,Tue,Wed,Thu,Fri, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "schedule", "params": {
+		"windows": [{"start": "13:30", "end": "20:00", "days": ["Mon", "Tue", "Wed", "Thu", "Fri"]}],
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}
+	}}]}`, string(value.([]byte)))
+
+	var rescanned triggerfns.TriggerFns
+	require.NoError(t, rescanned.Scan(value))
+	assert.True(t, tfns.Equals(rescanned))
+	assert.Equal(t, []bool{true}, pollAtTimes(t, rescanned.Clone(), "2020-06-01T15:00:00Z"))
+}
+
+func TestSchedule_BadParams(t *testing.T) {
+	const inner = `"triggerFn": {"type": "staleness", "params": 60}`
+	for _, windows := range []string{
+		`[]`,
+		`[{"start": "9:30", "end": "16:00"}]`,
+		`[{"start": "09:30", "end": "24:01"}]`,
+		`[{"start": "09:60", "end": "16:00"}]`,
+		`[{"start": "09:30", "end": "09:30"}]`,
+		`[{"start": "09:30", "end": "16:00", "days": ["Monday"]}]`,
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, tfns.Scan(`{"schedule": {"windows": `+windows+`, `+inner+`}}`), windows)
+	}
+}
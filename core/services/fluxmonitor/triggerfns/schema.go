@@ -0,0 +1,159 @@
+package triggerfns
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// SchemaProvider is implemented by trigger functions which can describe the
+// params their factory accepts, as a JSON Schema. An empty schema means the
+// params are not described.
+type SchemaProvider interface {
+	ParamSchema() string
+}
+
+const (
+	nonNegativeNumberSchema = `{"type": "number", "minimum": 0}`
+	numberSchema            = `{"type": "number"}`
+	innerTriggerFnsSchema   = `{"type": "object", "minProperties": 1}`
+	noParamsSchema          = `{"type": ["null", "object"], "maxProperties": 0}`
+	triggerFnJSONSchema     = `{"type": "object", "properties": {"type": {"type": "string"}, "params": {}}, "required": ["type"]}`
+)
+
+// relativeAndAbsoluteSchema describes the params of relativeWithFloor and
+// maxOfAbsRel.
+const relativeAndAbsoluteSchema = `{"type": "object", "properties": {` +
+	`"relative": {"type": "number", "minimum": 0}, "absolute": {"type": "number", "minimum": 0}}, ` +
+	`"required": ["relative", "absolute"], "additionalProperties": false}`
+
+// triggerFnSchemas holds the schema of each built in factory's params. Each
+// single parameter factory also accepts its parameter wrapped in an object,
+// but only the bare form is described here, unless the object form also takes
+// options, as relativeThreshold's does.
+var triggerFnSchemas = map[string]string{
+	"relativeThreshold": `{"anyOf": [` + nonNegativeNumberSchema + `, ` + noParamsSchema + `, ` +
+		`{"type": "object", "properties": {` +
+		`"threshold": ` + nonNegativeNumberSchema + `, "fireOnFirst": {"type": "boolean"}}, ` +
+		`"required": ["threshold"], "additionalProperties": false}]}`,
+	"relativeBps":       `{"type": "integer", "minimum": 0}`,
+	"absoluteThreshold": `{"type": ["number", "string"], "minimum": 0}`,
+	"relativeToNew":     nonNegativeNumberSchema,
+	"signedRelative":    nonNegativeNumberSchema,
+	"increaseThreshold": nonNegativeNumberSchema,
+	"decreaseThreshold": nonNegativeNumberSchema,
+	"hysteresis": `{"type": "object", "properties": {` +
+		`"upper": {"type": "number", "minimum": 0}, "lower": {"type": "number", "minimum": 0}}, ` +
+		`"required": ["upper", "lower"], "additionalProperties": false}`,
+	"staleness":         `{"type": "number", "exclusiveMinimum": 0}`,
+	"relativeWithFloor": relativeAndAbsoluteSchema,
+	"maxOfAbsRel":       relativeAndAbsoluteSchema,
+	"band": `{"type": "object", "properties": {` +
+		`"upPct": {"type": "number", "minimum": 0}, "downPct": {"type": "number", "minimum": 0}}, ` +
+		`"required": ["upPct", "downPct"], "additionalProperties": false}`,
+	"zscore": `{"type": "object", "properties": {` +
+		`"windowSize": {"type": "integer", "minimum": 2}, "sigma": {"type": "number", "exclusiveMinimum": 0}}, ` +
+		`"required": ["windowSize", "sigma"], "additionalProperties": false}`,
+	"ewmaThreshold": `{"type": "object", "properties": {` +
+		`"alpha": {"type": "number", "exclusiveMinimum": 0, "maximum": 1}, "threshold": {"type": "number", "minimum": 0}}, ` +
+		`"required": ["alpha", "threshold"], "additionalProperties": false}`,
+	"maCrossover": `{"type": "object", "properties": {` +
+		`"shortWindow": {"type": "integer", "minimum": 1}, "longWindow": {"type": "integer", "minimum": 2}}, ` +
+		`"required": ["shortWindow", "longWindow"], "additionalProperties": false}`,
+	"medianDeviation": `{"type": "object", "properties": {` +
+		`"windowSize": {"type": "integer", "minimum": 1}, "threshold": {"type": "number", "minimum": 0}}, ` +
+		`"required": ["windowSize", "threshold"], "additionalProperties": false}`,
+	"reentry": `{"type": "object", "properties": {` +
+		`"min": {"type": ["number", "string"]}, "max": {"type": ["number", "string"]}}, ` +
+		`"required": ["min", "max"], "additionalProperties": false}`,
+	"adaptiveThreshold": `{"type": "object", "properties": {` +
+		`"base": {"type": "number", "minimum": 0}, "multiplier": {"type": "number", "minimum": 0}, ` +
+		`"windowSize": {"type": "integer", "minimum": 2}}, ` +
+		`"required": ["base", "multiplier", "windowSize"], "additionalProperties": false}`,
+	"crossing":           numberSchema,
+	"floor":              numberSchema,
+	"ceiling":            numberSchema,
+	"percentOfReference": nonNegativeNumberSchema,
+	"logRatio":           nonNegativeNumberSchema,
+	"always":             noParamsSchema,
+	"never":              noParamsSchema,
+	"and":                innerTriggerFnsSchema,
+	"or":                 innerTriggerFnsSchema,
+	"not":                triggerFnJSONSchema,
+	"onlyOnChange":       triggerFnJSONSchema,
+	"override":           triggerFnJSONSchema,
+	"requireReference":   triggerFnJSONSchema,
+	"quorum": `{"type": "object", "properties": {` +
+		`"threshold": {"type": "integer", "minimum": 1}, "triggers": ` + innerTriggerFnsSchema + `}, ` +
+		`"required": ["threshold", "triggers"], "additionalProperties": false}`,
+	"scaled": `{"type": "object", "properties": {` +
+		`"decimals": {"type": "integer", "minimum": 0, "maximum": 255}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["decimals", "triggerFn"], "additionalProperties": false}`,
+	"weightedQuorum": `{"type": "object", "properties": {` +
+		`"weightThreshold": {"type": "number", "exclusiveMinimum": 0}, "triggers": {"type": "array", "minItems": 1, ` +
+		`"items": {"type": "object", "properties": {"weight": {"type": "number", "exclusiveMinimum": 0}, ` +
+		`"type": {"type": "string"}, "params": {}}, "required": ["weight", "type"], "additionalProperties": false}}}, ` +
+		`"required": ["weightThreshold", "triggers"], "additionalProperties": false}`,
+	"submissionBounds": `{"type": "object", "properties": {` +
+		`"min": {"type": ["number", "string"]}, "max": {"type": ["number", "string"]}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["min", "max", "triggerFn"], "additionalProperties": false}`,
+	"minBlockGap": `{"type": "object", "properties": {` +
+		`"blocks": {"type": "integer", "minimum": 1}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["blocks", "triggerFn"], "additionalProperties": false}`,
+	"cooldown": `{"type": "object", "properties": {` +
+		`"period": {"type": "string"}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["period", "triggerFn"], "additionalProperties": false}`,
+	"schedule": `{"type": "object", "properties": {` +
+		`"windows": {"type": "array", "minItems": 1, "items": {"type": "object", "properties": {` +
+		`"start": {"type": "string", "pattern": "^([01][0-9]|2[0-4]):[0-5][0-9]$"}, ` +
+		`"end": {"type": "string", "pattern": "^([01][0-9]|2[0-4]):[0-5][0-9]$"}, ` +
+		`"days": {"type": "array", "items": {"enum": ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"]}}}, ` +
+		`"required": ["start", "end"], "additionalProperties": false}}, ` +
+		`"triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["windows", "triggerFn"], "additionalProperties": false}`,
+	"gapAware": `{"type": "object", "properties": {` +
+		`"maxGap": {"type": "string"}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["maxGap", "triggerFn"], "additionalProperties": false}`,
+	"debounce": `{"type": "object", "properties": {` +
+		`"count": {"type": "integer", "minimum": 1}, "triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["count", "triggerFn"], "additionalProperties": false}`,
+	"rateLimit": `{"type": "object", "properties": {` +
+		`"maxReports": {"type": "integer", "minimum": 1}, "window": {"type": "string"}, ` +
+		`"triggerFn": ` + triggerFnJSONSchema + `}, ` +
+		`"required": ["maxReports", "window", "triggerFn"], "additionalProperties": false}`,
+}
+
+// TriggerFnSchema returns the JSON Schema of the params of the trigger
+// function registered under name. It returns an error if there is no such
+// function, or if its params are not described.
+func TriggerFnSchema(name string) (string, error) {
+	triggerFnFactoriesMu.RLock()
+	_, registered := triggerFnFactories[name]
+	triggerFnFactoriesMu.RUnlock()
+	if !registered {
+		return "", fmt.Errorf("%w %s", ErrUnknownTriggerFn, name)
+	}
+	schema, ok := triggerFnSchemas[name]
+	if !ok {
+		return "", errors.Errorf("trigger function %s has no parameter schema", name)
+	}
+	return schema, nil
+}
+
+func (f thresholdTriggerFn) ParamSchema() string   { return triggerFnSchemas[f.factory] }
+func (h *hysteresisTriggerFn) ParamSchema() string { return triggerFnSchemas["hysteresis"] }
+func (s *stalenessTriggerFn) ParamSchema() string  { return triggerFnSchemas["staleness"] }
+func (r relativeWithFloorTriggerFn) ParamSchema() string {
+	return triggerFnSchemas[r.factory]
+}
+func (b bandTriggerFn) ParamSchema() string     { return triggerFnSchemas["band"] }
+func (z *zscoreTriggerFn) ParamSchema() string  { return triggerFnSchemas["zscore"] }
+func (e *ewmaTriggerFn) ParamSchema() string    { return triggerFnSchemas["ewmaThreshold"] }
+func (c crossingTriggerFn) ParamSchema() string { return triggerFnSchemas["crossing"] }
+func (b boundTriggerFn) ParamSchema() string    { return triggerFnSchemas[b.factory] }
+func (p percentOfReferenceTriggerFn) ParamSchema() string {
+	return triggerFnSchemas["percentOfReference"]
+}
+func (c compositeTriggerFn) ParamSchema() string { return triggerFnSchemas[c.factory] }
+func (n notTriggerFn) ParamSchema() string       { return triggerFnSchemas["not"] }
+func (c *cooldownTriggerFn) ParamSchema() string { return triggerFnSchemas["cooldown"] }
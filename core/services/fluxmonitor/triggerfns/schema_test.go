@@ -0,0 +1,61 @@
+package triggerfns_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerFnSchema(t *testing.T) {
+	schema, err := triggerfns.TriggerFnSchema("staleness")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type": "number", "exclusiveMinimum": 0}`, schema)
+
+	schema, err = triggerfns.TriggerFnSchema("relativeThreshold")
+	require.NoError(t, err)
+	assert.Contains(t, schema, `"fireOnFirst": {"type": "boolean"}`)
+
+	schema, err = triggerfns.TriggerFnSchema("band")
+	require.NoError(t, err)
+	var band struct {
+		Type       string                     `json:"type"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(schema), &band))
+	assert.Equal(t, "object", band.Type)
+	assert.Contains(t, band.Properties, "upPct")
+	assert.Contains(t, band.Properties, "downPct")
+	assert.ElementsMatch(t, []string{"upPct", "downPct"}, band.Required)
+}
+
+func TestTriggerFnSchema_EveryBuiltInIsValidJSON(t *testing.T) {
+	for _, name := range triggerfns.RegisteredTriggerFns() {
+		schema, err := triggerfns.TriggerFnSchema(name)
+		require.NoError(t, err, name)
+		assert.True(t, json.Valid([]byte(schema)), name)
+	}
+}
+
+func TestTriggerFnSchema_Errors(t *testing.T) {
+	_, err := triggerfns.TriggerFnSchema("frobnicate")
+	assert.EqualError(t, err, "unknown trigger function frobnicate")
+
+	require.NoError(t, triggerfns.RegisterTriggerFn("squareDeviation", newSquareDeviation))
+	defer triggerfns.ExportedUnregisterTriggerFn("squareDeviation")
+	_, err = triggerfns.TriggerFnSchema("squareDeviation")
+	assert.EqualError(t, err, "trigger function squareDeviation has no parameter schema")
+}
+
+func TestSchemaProvider(t *testing.T) {
+	tfn := mustScanOne(t, `{"hysteresis": {"upper": 0.02, "lower": 0.01}}`)
+	provider, ok := tfn.(triggerfns.SchemaProvider)
+	require.True(t, ok)
+	schema, err := triggerfns.TriggerFnSchema("hysteresis")
+	require.NoError(t, err)
+	assert.Equal(t, schema, provider.ParamSchema())
+}
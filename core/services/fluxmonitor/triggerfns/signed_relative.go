@@ -0,0 +1,30 @@
+package triggerfns
+
+import "github.com/shopspring/decimal"
+
+// signedRelativeFactory returns a TriggerFn which fires when new differs
+// from current by at least the given fraction of the mean of their
+// magnitudes, as measured by MidpointDeviation. It suits feeds such as
+// spreads, which may legitimately be negative or cross zero: the deviation
+// is the same whichever of current and new is the larger, and a move to,
+// from or across zero counts as 200%, so any threshold up to 2 fires on it.
+func signedRelativeFactory(params interface{}) (TriggerFn, error) {
+	t, parameter, err := exactNonNegative("signedRelative", params)
+	if err != nil {
+		return nil, err
+	}
+	two := decimal.NewFromInt(2)
+	return thresholdTriggerFn{
+		factory:   "signedRelative",
+		parameter: parameter,
+		threshold: t,
+		triggering: func(current, new decimal.Decimal) bool {
+			if current.IsZero() && new.IsZero() {
+				return false
+			}
+			// Compared without dividing, so that no rounding is involved.
+			return !AbsoluteDeviation(current, new).Mul(two).LessThan(t.Mul(current.Abs().Add(new.Abs())))
+		},
+		measure: midpointMeasure,
+	}, nil
+}
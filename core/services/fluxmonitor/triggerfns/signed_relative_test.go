@@ -0,0 +1,113 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMidpointDeviation(t *testing.T) {
+	for _, test := range []struct {
+		current, new, expected string
+	}{
+		{"100", "100", "0"},
+		{"0", "0", "0"},
+		{"90", "110", "0.2"},
+		{"110", "90", "0.2"},
+		{"-90", "-110", "0.2"},
+		{"0", "5", "2"},
+		{"5", "0", "2"},
+		{"1", "-3", "2"},
+		{"-3", "1", "2"},
+	} {
+		actual := triggerfns.MidpointDeviation(decimal.RequireFromString(test.current), decimal.RequireFromString(test.new))
+		assert.Equal(t, test.expected, actual.String(), "%s to %s", test.current, test.new)
+	}
+}
+
+// TestRelativeThreshold_AcrossZero pins down how relativeThreshold treats a
+// move across zero: it always exceeds 100% of current, however small the
+// answers are, and more so the further new lands past zero.
+func TestRelativeThreshold_AcrossZero(t *testing.T) {
+	for _, test := range []struct {
+		current, new, deviation string
+	}{
+		{"2", "-2", "2"},
+		{"-2", "2", "2"},
+		{"2", "-0.02", "1.01"},
+		{"-0.02", "2", "101"},
+	} {
+		current, new := decimal.RequireFromString(test.current), decimal.RequireFromString(test.new)
+		assert.Equal(t, test.deviation, triggerfns.RelativeDeviation(current, new).String(),
+			"%s to %s", test.current, test.new)
+	}
+}
+
+func TestSignedRelative_AcrossZero(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "relativeThreshold", "params": 1.5},
+		{"type": "signedRelative", "params": 1.5}
+	]`))
+	relative := tfns.FilterByFactory("relativeThreshold")[0]
+	signed := tfns.FilterByFactory("signedRelative")[0]
+	assert.Equal(t, 1.5, signed.Parameters())
+
+	tests := []struct {
+		name                     string
+		current, new             string
+		wantRelative, wantSigned bool
+	}{
+		{"positive to negative", "2", "-0.02", false, true},
+		{"negative to positive", "-0.02", "2", true, true},
+		{"positive to slightly negative", "0.02", "-0.01", true, true},
+		{"negative to slightly positive", "-0.02", "0.01", true, true},
+		{"to zero", "2", "0", false, true},
+		{"from zero", "0", "2", false, true},
+		// Away from zero, both measure about the same.
+		{"small rise", "100", "101", false, false},
+		{"unchanged", "0", "0", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.RequireFromString(test.current), decimal.RequireFromString(test.new)
+			fired, err := relative.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantRelative, fired, "relativeThreshold")
+			fired, err = signed.Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantSigned, fired, "signedRelative")
+		})
+	}
+}
+
+func TestSignedRelative_Threshold(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"signedRelative": 0.2}`))
+
+	for _, test := range []struct {
+		current, new string
+		fired        bool
+	}{
+		{"90", "110", true},
+		{"110", "90", true},
+		{"-90", "-110", true},
+		{"90", "109.9", false},
+		{"-90", "-109.9", false},
+	} {
+		result, err := triggerfns.TriggeringWithReason(context.Background(), tfns[0],
+			decimal.RequireFromString(test.current), decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, test.fired, result.Fired, "%s to %s: %s", test.current, test.new, result.Reason)
+	}
+
+	result, err := triggerfns.TriggeringWithReason(context.Background(), tfns[0],
+		decimal.NewFromInt(90), decimal.NewFromInt(110), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.Equal(t, "midpoint deviation 20.00% >= 20.00%", result.Reason)
+}
@@ -0,0 +1,82 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Clocked is implemented by trigger functions which read the current time.
+// They use the system clock until SetClock is called.
+type Clocked interface {
+	SetClock(clock utils.AfterNower)
+}
+
+// SetClock hands clock to every function in f which reads the current time,
+// including those nested inside composites. It should be called before f is
+// evaluated.
+func (f TriggerFns) SetClock(clock utils.AfterNower) {
+	for _, tfn := range f {
+		if c, ok := tfn.(Clocked); ok {
+			c.SetClock(clock)
+		}
+	}
+}
+
+// maxStalenessSeconds is the longest age a time.Duration can represent.
+var maxStalenessSeconds = float64(math.MaxInt64) / float64(time.Second)
+
+// stalenessTriggerFn fires once the last report is at least maxAge old,
+// regardless of how far the answer has moved.
+type stalenessTriggerFn struct {
+	seconds float64
+	maxAge  time.Duration
+	clock   utils.AfterNower
+}
+
+// stalenessThresholdFactory expects the maximum age of a report in seconds,
+// either as a bare number or as {"seconds": number}.
+func stalenessThresholdFactory(params interface{}) (TriggerFn, error) {
+	var p struct {
+		Seconds float64 `json:"seconds"`
+	}
+	if err := decodeParams("staleness", params, &p); err != nil {
+		return nil, err
+	}
+	seconds := p.Seconds
+	if seconds <= 0 || seconds > maxStalenessSeconds {
+		return nil, errors.Errorf("staleness requires a positive number of seconds no greater than %v, got %v",
+			maxStalenessSeconds, seconds)
+	}
+	return &stalenessTriggerFn{
+		seconds: seconds,
+		maxAge:  time.Duration(seconds * float64(time.Second)),
+		clock:   utils.Clock{},
+	}, nil
+}
+
+// Triggering requires tc.LastReportedAt.
+func (s *stalenessTriggerFn) Triggering(ctx context.Context, _, _ decimal.Decimal, tc TriggerContext) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if tc.LastReportedAt.IsZero() {
+		return false, errors.New("staleness requires the last report time")
+	}
+	return s.clock.Now().Sub(tc.LastReportedAt) >= s.maxAge, nil
+}
+
+// Clone returns a staleness function with s's maximum age, using s's clock.
+func (s *stalenessTriggerFn) Clone() TriggerFn {
+	clone := *s
+	return &clone
+}
+
+func (s *stalenessTriggerFn) SetClock(clock utils.AfterNower) { s.clock = clock }
+func (s *stalenessTriggerFn) Factory() string                 { return "staleness" }
+func (s *stalenessTriggerFn) Parameters() interface{}         { return s.seconds }
@@ -0,0 +1,87 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/internal/mocks"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleness_FiresOnceIntervalElapses(t *testing.T) {
+	lastReportedAt := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"staleness": 3600}`))
+	require.Len(t, tfns, 1)
+	tfns.SetClock(clock)
+	staleness := tfns[0]
+	assert.Equal(t, float64(3600), staleness.Parameters())
+
+	price := decimal.NewFromInt(100)
+	steps := []struct {
+		elapsed  time.Duration
+		expected bool
+	}{
+		{0, false},
+		{time.Hour - time.Second, false},
+		{time.Hour, true},
+		{2 * time.Hour, true},
+	}
+	for _, step := range steps {
+		clock.On("Now").Return(lastReportedAt.Add(step.elapsed)).Once()
+		fired, err := staleness.Triggering(context.Background(), price, price, triggerfns.TriggerContext{LastReportedAt: lastReportedAt})
+		require.NoError(t, err)
+		assert.Equal(t, step.expected, fired, "after %s", step.elapsed)
+	}
+	clock.AssertExpectations(t)
+}
+
+func TestStaleness_ReadsLastReportedAtFromTriggerContext(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	clock := new(mocks.AfterNower)
+	clock.On("Now").Return(now)
+
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"staleness": 60}`))
+	tfns.SetClock(clock)
+
+	price := decimal.NewFromInt(100)
+	for _, test := range []struct {
+		lastReportedAt time.Time
+		expected       bool
+	}{
+		{now.Add(-time.Minute), true},
+		{now.Add(-time.Second), false},
+	} {
+		fired, err := tfns[0].Triggering(context.Background(), price, price, triggerfns.TriggerContext{
+			LastReportedAt: test.lastReportedAt,
+			RoundID:        7,
+			OnchainValue:   price,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, fired, "last reported at %s", test.lastReportedAt)
+	}
+}
+
+func TestStaleness_RequiresLastReportTime(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"staleness": 60}`))
+	price := decimal.NewFromInt(100)
+
+	_, err := tfns[0].Triggering(context.Background(), price, price, triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "staleness requires the last report time")
+}
+
+func TestStaleness_BadParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"staleness": "1h"}`))
+	assert.Error(t, tfns.Scan(`{"staleness": 0}`))
+	assert.Error(t, tfns.Scan(`{"staleness": 1e12}`))
+}
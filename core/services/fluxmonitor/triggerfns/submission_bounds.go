@@ -0,0 +1,113 @@
+package triggerfns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	triggerFnFactories["submissionBounds"] = FactoryFunc(submissionBoundsFactory)
+}
+
+// submissionBoundsParams are the params of submissionBounds, e.g.
+// {"min": "1", "max": "100000000000", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}.
+// The bounds may be numbers or decimal strings, so that large on-chain
+// values are exact.
+type submissionBoundsParams struct {
+	Min       interface{}   `json:"min"`
+	Max       interface{}   `json:"max"`
+	TriggerFn triggerFnJSON `json:"triggerFn"`
+}
+
+// submissionBoundsTriggerFn fires when its inner function does, unless new
+// lies outside [min, max]. A FluxAggregator rejects submissions outside its
+// minSubmissionValue and maxSubmissionValue, so reporting them would only
+// waste gas on a revert. The inner function is evaluated either way, so that
+// a stateful one sees every answer.
+type submissionBoundsTriggerFn struct {
+	min, max       decimal.Decimal
+	minRaw, maxRaw interface{}
+	inner          TriggerFn
+}
+
+func submissionBoundsFactory(params interface{}) (TriggerFn, error) {
+	var p submissionBoundsParams
+	if err := decodeParams("submissionBounds", params, &p); err != nil {
+		return nil, err
+	}
+	min, err := decimalParam("submissionBounds", "min", p.Min)
+	if err != nil {
+		return nil, err
+	}
+	max, err := decimalParam("submissionBounds", "max", p.Max)
+	if err != nil {
+		return nil, err
+	}
+	if min.GreaterThan(max) {
+		return nil, errors.Errorf("submissionBounds requires min to be at most max, got %s and %s", min, max)
+	}
+	inner, err := makeTriggerFn(p.TriggerFn.Type, p.TriggerFn.Params)
+	if err != nil {
+		return nil, errors.Wrap(err, "while constructing submissionBounds's inner trigger function")
+	}
+	return submissionBoundsTriggerFn{min: min, max: max, minRaw: p.Min, maxRaw: p.Max, inner: inner}, nil
+}
+
+// decimalParam reads the named parameter of factory, which must be a number
+// or a decimal string.
+func decimalParam(factory, name string, v interface{}) (decimal.Decimal, error) {
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	default:
+		return decimal.Decimal{}, errors.Errorf("%s requires %s to be a number or a decimal string, got %v", factory, name, v)
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, errors.Wrapf(err, "%s has a malformed %s", factory, name)
+	}
+	return d, nil
+}
+
+func (s submissionBoundsTriggerFn) Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	fired, err := s.inner.Triggering(ctx, current, new, tc)
+	if err != nil || !fired {
+		return false, err
+	}
+	return !new.LessThan(s.min) && !new.GreaterThan(s.max), nil
+}
+
+// Clone returns submission bounds around a clone of s's inner function.
+func (s submissionBoundsTriggerFn) Clone() TriggerFn {
+	s.inner = cloneTriggerFn(s.inner)
+	return s
+}
+
+func (s submissionBoundsTriggerFn) Parameters() interface{} {
+	return submissionBoundsParams{
+		Min:       s.minRaw,
+		Max:       s.maxRaw,
+		TriggerFn: triggerFnJSON{Type: s.inner.Factory(), Params: s.inner.Parameters()},
+	}
+}
+
+func (s submissionBoundsTriggerFn) SetClock(clock utils.AfterNower) {
+	TriggerFns{s.inner}.SetClock(clock)
+}
+func (s submissionBoundsTriggerFn) stateful() bool      { return isStateful(s.inner) }
+func (s submissionBoundsTriggerFn) Factory() string     { return "submissionBounds" }
+func (s submissionBoundsTriggerFn) ParamSchema() string { return triggerFnSchemas["submissionBounds"] }
+func (s submissionBoundsTriggerFn) String() string {
+	return fmt.Sprintf("submissionBounds(%s, %s, %s)", s.min, s.max, triggerFnString(s.inner))
+}
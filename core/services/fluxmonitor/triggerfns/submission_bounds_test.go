@@ -0,0 +1,76 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const submissionBoundsSpec = `{"submissionBounds": {"min": 50, "max": "1000000000000000000000",
+	"triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`
+
+func TestSubmissionBounds(t *testing.T) {
+	tfn := mustScanOne(t, submissionBoundsSpec)
+
+	tests := []struct {
+		name          string
+		current, new  string
+		wantTriggered bool
+	}{
+		{"deviation within bounds", "100", "110", true},
+		{"deviation below min", "100", "40", false},
+		{"deviation to min", "100", "50", true},
+		{"deviation above max", "1000000000000000000000", "1100000000000000000000", false},
+		{"deviation to max", "990000000000000000000", "1000000000000000000000", true},
+		{"no deviation within bounds", "100", "100.5", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fired, err := tfn.Triggering(context.Background(), decimal.RequireFromString(test.current),
+				decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTriggered, fired)
+		})
+	}
+}
+
+func TestSubmissionBounds_ValueScanRoundTrip(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(submissionBoundsSpec))
+	require.NoError(t, tfns.Validate())
+	assert.Equal(t, "submissionBounds(50, 1000000000000000000000, relativeThreshold(0.01))", tfns.String())
+
+	value, err := tfns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "submissionBounds", "params": {"min": 50, "max": "1000000000000000000000",
+		"triggerFn": {"type": "relativeThreshold", "params": 0.01}}}]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	again, err := scanned.Value()
+	require.NoError(t, err)
+	assert.Equal(t, value, again)
+}
+
+func TestSubmissionBounds_BadParams(t *testing.T) {
+	inner := `"triggerFn": {"type": "relativeThreshold", "params": 0.01}`
+	tests := []struct{ name, spec string }{
+		{"missing min", `{"submissionBounds": {"max": 100, ` + inner + `}}`},
+		{"missing triggerFn", `{"submissionBounds": {"min": 0, "max": 100}}`},
+		{"min above max", `{"submissionBounds": {"min": 101, "max": 100, ` + inner + `}}`},
+		{"malformed max", `{"submissionBounds": {"min": 0, "max": "lots", ` + inner + `}}`},
+		{"non-numeric min", `{"submissionBounds": {"min": true, "max": 100, ` + inner + `}}`},
+		{"unknown inner", `{"submissionBounds": {"min": 0, "max": 100, "triggerFn": {"type": "squareDeviation", "params": 4}}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.spec))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package triggerfns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// tracer is implemented by trigger functions built from others, to show how
+// each of those was evaluated.
+type tracer interface {
+	trace(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (string, bool, error)
+}
+
+// Trace evaluates f as ShouldReport does, and also returns a description of
+// how each function, and each function inside a composite, was evaluated,
+// e.g. "and[relativeThreshold(0.005) (relative deviation 0.60% >= 0.50%)=true,
+// absoluteThreshold(5) (absolute deviation 0.6 < 5)=false]=false". It is for
+// debugging specs which don't fire when expected.
+//
+// Unlike ShouldReport, Trace evaluates every function, even those whose
+// result can't change the outcome, so a stateful f may see answers it
+// otherwise wouldn't. Trace a Clone of f to leave f's state as it was.
+func (f TriggerFns) Trace(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (string, bool, error) {
+	if len(f) == 1 {
+		return traceTriggerFn(ctx, f[0], current, new, tc)
+	}
+	return compositeTriggerFn{factory: "or", fns: f, any: true}.trace(ctx, current, new, tc)
+}
+
+// traceTriggerFn evaluates tfn, describing the evaluation as Trace does.
+func traceTriggerFn(ctx context.Context, tfn TriggerFn, current, new decimal.Decimal, tc TriggerContext) (string, bool, error) {
+	if t, ok := tfn.(tracer); ok {
+		return t.trace(ctx, current, new, tc)
+	}
+	result, err := TriggeringWithReason(ctx, tfn, current, new, tc)
+	name := triggerFnString(tfn)
+	if err != nil {
+		return name + "=error: " + err.Error(), false, err
+	}
+	if _, ok := tfn.(Reasoner); ok {
+		name += " (" + result.Reason + ")"
+	}
+	return fmt.Sprintf("%s=%t", name, result.Fired), result.Fired, nil
+}
+
+func (c compositeTriggerFn) trace(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (string, bool, error) {
+	traces := make([]string, len(c.fns))
+	var firstErr error
+	settled := false
+	for i, tfn := range c.fns {
+		if err := ctx.Err(); err != nil {
+			return "", false, err
+		}
+		var fired bool
+		var err error
+		traces[i], fired, err = traceTriggerFn(ctx, tfn, current, new, tc)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if fired == c.any {
+			settled = true
+		}
+	}
+	fired := firstErr == nil && settled == c.any
+	return fmt.Sprintf("%s[%s]=%t", c.factory, strings.Join(traces, ", "), fired), fired, firstErr
+}
+
+func (n notTriggerFn) trace(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (string, bool, error) {
+	inner, fired, err := traceTriggerFn(ctx, n.inner, current, new, tc)
+	fired = err == nil && !fired
+	return fmt.Sprintf("not[%s]=%t", inner, fired), fired, err
+}
+
+// trace records the evaluation, as Triggering does, and shows where a dry run
+// suppressed it.
+func (m meteredTriggerFn) trace(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (string, bool, error) {
+	inner, fired, err := traceTriggerFn(ctx, m.TriggerFn, current, new, tc)
+	m.record(current, new, fired, err)
+	if m.dryRun {
+		return fmt.Sprintf("dryRun[%s]=false", inner), false, err
+	}
+	return inner, fired, err
+}
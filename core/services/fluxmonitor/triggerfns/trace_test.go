@@ -0,0 +1,72 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrace(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		new       string
+		wantTrace string
+		wantFired bool
+	}{
+		{"single", `{"relativeThreshold": 0.005}`, "100.6",
+			"relativeThreshold(0.005) (relative deviation 0.60% >= 0.50%)=true", true},
+		{"mixed composite", `{"and": {"relativeThreshold": 0.005, "absoluteThreshold": 5}}`, "100.6",
+			"and[absoluteThreshold(5) (absolute deviation 0.6 < 5)=false, " +
+				"relativeThreshold(0.005) (relative deviation 0.60% >= 0.50%)=true]=false", false},
+		{"nested", `{"or": {"and": {"relativeThreshold": 0.005, "floor": 90}, "not": {"type": "ceiling", "params": 110}}}`, "100.6",
+			"or[and[floor(90)=false, relativeThreshold(0.005) (relative deviation 0.60% >= 0.50%)=true]=false, " +
+				"not[ceiling(110)=false]=true]=true", true},
+		{"several", `{"relativeThreshold": 0.01, "absoluteThreshold": 0.5}`, "100.6",
+			"or[absoluteThreshold(0.5) (absolute deviation 0.6 >= 0.5)=true, " +
+				"relativeThreshold(0.01) (relative deviation 0.60% < 1.00%)=false]=true", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			require.NoError(t, tfns.Scan(test.spec))
+			current, new := decimal.NewFromInt(100), decimal.RequireFromString(test.new)
+
+			trace, fired, err := tfns.Trace(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantTrace, trace)
+			assert.Equal(t, test.wantFired, fired)
+
+			shouldReport, err := tfns.ShouldReport(context.Background(), current, new, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, shouldReport, fired, "Trace disagrees with ShouldReport")
+		})
+	}
+}
+
+func TestTrace_Error(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"and": {"relativeThreshold": 0.005, "staleness": 60}}`))
+	trace, fired, err := tfns.Trace(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(101),
+		triggerfns.TriggerContext{})
+	assert.EqualError(t, err, "staleness requires the last report time")
+	assert.False(t, fired)
+	assert.Contains(t, trace, "staleness(60)=error: staleness requires the last report time")
+}
+
+func TestTrace_DryRun(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`{"relativeThreshold": 0.005}`))
+	sink := &recordingSink{}
+	trace, fired, err := tfns.DryRun(sink).Trace(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(101),
+		triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.False(t, fired)
+	assert.Equal(t, "dryRun[relativeThreshold(0.005) (relative deviation 1.00% >= 0.50%)=true]=false", trace)
+	assert.Len(t, sink.records, 1)
+}
@@ -0,0 +1,643 @@
+// Package triggerfns contains the trigger functions a flux monitor job uses
+// to decide whether a newly polled answer warrants a report.
+package triggerfns
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+)
+
+// TriggerFn decides whether the move from current to new should be reported.
+type TriggerFn interface {
+	// Triggering returns true if a report should be made. Implementations
+	// which may block or call out should return ctx.Err() once ctx is done.
+	// It may be called from several goroutines at once, so implementations
+	// which keep state between calls must guard it, as the built in ones do
+	// with a mutex. Stateless ones, such as relativeThreshold, take no lock.
+	Triggering(ctx context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error)
+	// Factory is the name under which the function is registered.
+	Factory() string
+	// Parameters returns the params the function was constructed with, in a
+	// form which can be fed back into its factory.
+	Parameters() interface{}
+}
+
+// TriggerContext is what the flux monitor knows about the feed beyond the
+// current and new answers. Trigger functions which don't need it ignore it,
+// and its zero value is a valid argument for them.
+type TriggerContext struct {
+	// LastReportedAt is when the current answer was reported, or the zero
+	// time if that isn't known.
+	LastReportedAt time.Time
+	// RoundID is the round the new answer would be reported in.
+	RoundID uint32
+	// OnchainValue is the answer currently held by the aggregator contract.
+	OnchainValue decimal.Decimal
+	// Reference is a value supplied by the feed's consumer which the answer
+	// is measured against, or nil if there is none.
+	Reference *decimal.Decimal
+	// ReferenceStale is set when the source of Reference has stopped
+	// updating, so that Reference can't be relied on.
+	ReferenceStale bool
+	// OverrideReport is set when an operator has asked for a report
+	// regardless of the answers, which the override trigger function honors.
+	OverrideReport bool
+	// BlockNumber is the height of the block the new answer was read at, or
+	// zero if that isn't known.
+	BlockNumber uint64
+	// LastReportedBlock is the height of the block the current answer was
+	// reported in, or zero if that isn't known.
+	LastReportedBlock uint64
+	// LastSampleAt is when the answer polled before new was read, or the
+	// zero time if that isn't known.
+	LastSampleAt time.Time
+}
+
+// TriggerFns is a collection of TriggerFn, persisted as
+// {"version": 2, "functions": [...]}, listing {"type": factory, "params":
+// params} objects.
+type TriggerFns []TriggerFn
+
+// serializationVersion is the version of the form Value writes. Version 1
+// is the object form keyed by factory name, which carries no version.
+const serializationVersion = 2
+
+// versionedTriggerFns is the form Value writes.
+type versionedTriggerFns struct {
+	Version   int             `json:"version"`
+	Functions []triggerFnJSON `json:"functions"`
+}
+
+var (
+	_ driver.Valuer    = TriggerFns{}
+	_ sql.Scanner      = &TriggerFns{}
+	_ json.Marshaler   = TriggerFns{}
+	_ json.Unmarshaler = &TriggerFns{}
+)
+
+// Value returns this instance serialized for database storage, in the latest
+// version of the format. The output is independent of the order of f, so
+// equal sets serialize to equal bytes.
+func (f TriggerFns) Value() (driver.Value, error) {
+	sorted := append(TriggerFns{}, f...)
+	if err := sortTriggerFns(sorted); err != nil {
+		return nil, err
+	}
+	entries := []triggerFnJSON{}
+	for _, tfn := range sorted {
+		entries = append(entries, triggerFnJSON{Type: tfn.Factory(), Params: tfn.Parameters()})
+	}
+	return json.Marshal(versionedTriggerFns{Version: serializationVersion, Functions: entries})
+}
+
+// Scan reads the database value and returns an instance. It accepts the
+// versioned form written by Value, the older object form keyed by factory
+// name, which it treats as version 1, and a bare array of {"type", "params"}
+// objects, as written before the format was versioned. It treats NULL as
+// holding no functions. A value with no functions scans as DefaultTriggerFns
+// if SetDefaultOnEmpty has enabled them. The functions are ordered by factory
+// name, then by params. If any entry is invalid, Scan returns an error for
+// each such entry, naming its position in value. If SetRejectConflicts has
+// enabled it, Scan also rejects a value combining conflicting functions.
+func (f *TriggerFns) Scan(value interface{}) error {
+	entries, err := getTriggerFnEntries(value)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 && useDefaultOnEmpty() {
+		*f = DefaultTriggerFns()
+		return nil
+	}
+	var merr error
+	triggerFns := TriggerFns{}
+	for _, entry := range entries {
+		triggerFn, err := makeTriggerFn(entry.Type, entry.Params)
+		var paramErr ParamError
+		if err != nil && entry.Params == nil && errors.As(err, &paramErr) && paramErr.Factory == entry.Type {
+			if useSkipNullParams() {
+				logger.Warnw("Skipping trigger function with null params", "triggerFn", entry.Type, "path", entry.path)
+				continue
+			}
+			err = ParamError{Factory: entry.Type, Err: errors.Errorf("%s has null params", entry.Type)}
+		}
+		if err != nil {
+			merr = multierr.Append(merr, errors.Wrap(err, entry.path))
+			continue
+		}
+		triggerFns = append(triggerFns, triggerFn)
+	}
+	if merr != nil {
+		return merr
+	}
+	if useRejectConflicts() {
+		if err := checkConflicts("triggerFns", triggerFns); err != nil {
+			return err
+		}
+	}
+	if err := sortTriggerFns(triggerFns); err != nil {
+		return err
+	}
+	*f = triggerFns
+	return nil
+}
+
+// NewTriggerFns builds the trigger functions spec describes, in the object
+// form Scan accepts, e.g.
+// map[string]interface{}{"relativeThreshold": 0.01, "staleness": 60}.
+// Params are passed through encoding/json first, so they reach each factory
+// exactly as they would from the database. Like Scan, it returns an error for
+// each unknown or invalid entry.
+func NewTriggerFns(spec map[string]interface{}) (TriggerFns, error) {
+	b, err := json.Marshal(spec)
+	if _, ok := err.(*json.UnsupportedValueError); ok {
+		return nil, fmt.Errorf("%w: trigger function spec has a param of %v", ErrNotFinite, err)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "while serializing trigger function spec")
+	}
+	var f TriggerFns
+	if err := f.Scan(b); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// MarshalJSON returns the same encoding as Value, so that the API and
+// database forms match. An empty TriggerFns marshals with no functions.
+func (f TriggerFns) MarshalJSON() ([]byte, error) {
+	value, err := f.Value()
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
+
+// UnmarshalJSON accepts anything Scan does. JSON null leaves f unchanged.
+func (f *TriggerFns) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	return f.Scan(b)
+}
+
+// triggerFnJSON is the serialized form of a single TriggerFn.
+type triggerFnJSON struct {
+	Type   string      `json:"type"`
+	Params interface{} `json:"params"`
+
+	// path locates the entry in the value it was scanned from.
+	path string
+}
+
+// getTriggerFnEntries parses value, which must hold a versioned form, a JSON
+// array of {"type", "params"} objects or a JSON object mapping factory names
+// to params. A NULL or empty value holds no entries.
+func getTriggerFnEntries(value interface{}) ([]triggerFnJSON, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return nil, fmt.Errorf("Unable to convert %v of %T to JSON", value, value)
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("TriggerFns must be valid JSON, got %s", raw)
+	}
+	// Numbers are kept as json.Number, so that factories can read them
+	// exactly rather than through a float64.
+	var parsed interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("TriggerFns must be valid JSON, got %s", raw)
+	}
+	switch v := parsed.(type) {
+	case map[string]interface{}:
+		if _, versioned := v["version"]; versioned {
+			return getVersionedEntries(v)
+		}
+		entries := []triggerFnJSON{}
+		for name, params := range v {
+			entries = append(entries, triggerFnJSON{name, params, "triggerFns." + name})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Type < entries[j].Type })
+		return entries, nil
+	case []interface{}:
+		return getArrayEntries(v, "triggerFns")
+	default:
+		return nil, fmt.Errorf("TriggerFns must be a JSON array or object, got %s", bytes.TrimSpace(raw))
+	}
+}
+
+// getVersionedEntries returns the entries of v, the versioned form written
+// by Value. Versions later than serializationVersion are rejected, rather
+// than misread, so that a node which doesn't understand a newer format fails
+// loudly during a rolling upgrade.
+func getVersionedEntries(v map[string]interface{}) ([]triggerFnJSON, error) {
+	version, ok := v["version"].(json.Number)
+	if !ok || version.String() != strconv.Itoa(serializationVersion) {
+		return nil, fmt.Errorf("unsupported TriggerFns version %v; this node reads up to version %d",
+			v["version"], serializationVersion)
+	}
+	for key := range v {
+		if key != "version" && key != "functions" {
+			return nil, fmt.Errorf("TriggerFns version %d has unknown field %q", serializationVersion, key)
+		}
+	}
+	functions, ok := v["functions"].([]interface{})
+	if !ok && v["functions"] != nil {
+		return nil, fmt.Errorf("TriggerFns functions must be a JSON array, got %v", v["functions"])
+	}
+	return getArrayEntries(functions, "triggerFns.functions")
+}
+
+// getArrayEntries returns the entries of v, an array of {"type", "params"}
+// objects found at path.
+func getArrayEntries(v []interface{}, path string) ([]triggerFnJSON, error) {
+	entries := []triggerFnJSON{}
+	var merr error
+	for i, e := range v {
+		path := fmt.Sprintf("%s[%d]", path, i)
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			merr = multierr.Append(merr, fmt.Errorf("%s: must be a JSON object, got %v", path, e))
+			continue
+		}
+		name, ok := m["type"].(string)
+		if !ok {
+			merr = multierr.Append(merr, fmt.Errorf("%s: requires a string type, got %v", path, m["type"]))
+			continue
+		}
+		entries = append(entries, triggerFnJSON{name, m["params"], path})
+	}
+	if merr != nil {
+		return nil, merr
+	}
+	return entries, nil
+}
+
+// sortTriggerFns orders fns by factory name, then by serialized params.
+func sortTriggerFns(fns TriggerFns) error {
+	params := make([]string, len(fns))
+	for i, tfn := range fns {
+		serialized, err := json.Marshal(tfn.Parameters())
+		if err != nil {
+			return errors.Wrapf(err, "while serializing params of trigger function %s", tfn.Factory())
+		}
+		params[i] = string(serialized)
+	}
+	sort.Sort(byFactoryAndParams{fns, params})
+	return nil
+}
+
+// byFactoryAndParams sorts fns, keeping params, their serialized parameters,
+// in step.
+type byFactoryAndParams struct {
+	fns    TriggerFns
+	params []string
+}
+
+func (b byFactoryAndParams) Len() int { return len(b.fns) }
+
+func (b byFactoryAndParams) Less(i, j int) bool {
+	if b.fns[i].Factory() != b.fns[j].Factory() {
+		return b.fns[i].Factory() < b.fns[j].Factory()
+	}
+	return b.params[i] < b.params[j]
+}
+
+func (b byFactoryAndParams) Swap(i, j int) {
+	b.fns[i], b.fns[j] = b.fns[j], b.fns[i]
+	b.params[i], b.params[j] = b.params[j], b.params[i]
+}
+
+// Equals returns true if f and other hold the same functions with the same
+// parameters, irrespective of order.
+func (f TriggerFns) Equals(other TriggerFns) bool {
+	if len(f) != len(other) {
+		return false
+	}
+	a, b := append(TriggerFns{}, f...), append(TriggerFns{}, other...)
+	if sortTriggerFns(a) != nil || sortTriggerFns(b) != nil {
+		return false
+	}
+	for i := range a {
+		if !triggerFnEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// triggerFnEqual compares thresholds exactly as decimals, and any other
+// parameters by their serialized form.
+func triggerFnEqual(a, b TriggerFn) bool {
+	if a.Factory() != b.Factory() || triggerFnName(a) != triggerFnName(b) {
+		return false
+	}
+	ta, aIsThreshold := unwrapMetered(a).(thresholdTriggerFn)
+	tb, bIsThreshold := unwrapMetered(b).(thresholdTriggerFn)
+	if aIsThreshold && bIsThreshold {
+		return ta.threshold.Equal(tb.threshold) && ta.quietOnFirst == tb.quietOnFirst &&
+			ta.useOnchain == tb.useOnchain
+	}
+	pa, err := json.Marshal(a.Parameters())
+	if err != nil {
+		return false
+	}
+	pb, err := json.Marshal(b.Parameters())
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(pa, pb)
+}
+
+// String lists the functions in f with their parameters, e.g.
+// "relativeThreshold(0.005), absoluteThreshold(0.01)".
+func (f TriggerFns) String() string {
+	strs := make([]string, len(f))
+	for i, tfn := range f {
+		strs[i] = triggerFnString(tfn)
+	}
+	return strings.Join(strs, ", ")
+}
+
+// triggerFnString formats tfn with its String method if it has one, or else
+// as its factory name followed by its serialized params.
+func triggerFnString(tfn TriggerFn) string {
+	if s, ok := tfn.(fmt.Stringer); ok {
+		return s.String()
+	}
+	params, err := json.Marshal(tfn.Parameters())
+	if err != nil {
+		return fmt.Sprintf("%s(%v)", tfn.Factory(), tfn.Parameters())
+	}
+	return fmt.Sprintf("%s(%s)", tfn.Factory(), params)
+}
+
+// thresholdTriggerFn is a TriggerFn parameterized by a single threshold.
+type thresholdTriggerFn struct {
+	factory string
+	// parameter is the threshold as it was given, a float64, json.Number or
+	// decimal string, so that it serializes without losing digits.
+	parameter  interface{}
+	threshold  decimal.Decimal
+	triggering func(current, new decimal.Decimal) bool
+	measure    thresholdMeasure
+	// quietOnFirst is set when a relativeThreshold was configured with
+	// "fireOnFirst": false, so never fires while current is zero.
+	quietOnFirst bool
+	// useOnchain is set when a relativeThreshold was configured with
+	// "useOnchain": true, so measures from tc.OnchainValue where known.
+	useOnchain bool
+	// warning, if set, describes why parameter is unlikely to be intended.
+	warning string
+}
+
+// Triggering makes the same decision as TriggeringWithReason, without
+// computing the deviation, which it evaluates on every poll.
+func (f thresholdTriggerFn) Triggering(_ context.Context, current, new decimal.Decimal, tc TriggerContext) (bool, error) {
+	return f.triggering(f.current(current, tc), new), nil
+}
+
+func (f thresholdTriggerFn) TriggeringWithReason(_ context.Context, current, new decimal.Decimal, tc TriggerContext) (TriggerResult, error) {
+	current = f.current(current, tc)
+	fired := f.triggering(current, new)
+	deviation := f.measure.of(current, new)
+	return TriggerResult{Fired: fired, Reason: f.measure.reason(fired, current, new, deviation, f.threshold), Deviation: deviation}, nil
+}
+
+// current returns the answer f measures new against: tc.OnchainValue if f
+// uses it and it is known, and current otherwise. A zero OnchainValue is
+// taken as unknown, as the aggregator holds zero until its first round.
+func (f thresholdTriggerFn) current(current decimal.Decimal, tc TriggerContext) decimal.Decimal {
+	if f.useOnchain && !tc.OnchainValue.IsZero() {
+		return tc.OnchainValue
+	}
+	return current
+}
+
+func (f thresholdTriggerFn) Factory() string         { return f.factory }
+func (f thresholdTriggerFn) Parameters() interface{} { return f.parameter }
+func (f thresholdTriggerFn) paramsWarning() string   { return f.warning }
+func (f thresholdTriggerFn) String() string {
+	if !f.quietOnFirst && !f.useOnchain {
+		return fmt.Sprintf("%s(%v)", f.factory, f.parameter)
+	}
+	s := fmt.Sprintf("%s(%v", f.factory, f.threshold)
+	if f.quietOnFirst {
+		s += ", fireOnFirst: false"
+	}
+	if f.useOnchain {
+		s += ", useOnchain: true"
+	}
+	return s + ")"
+}
+
+// relativeThresholdFactory returns a TriggerFn which fires when new differs
+// from current by at least the given fraction of current. If current is zero,
+// it fires whenever new is nonzero, unless params are given in the form
+// {"threshold": 0.01, "fireOnFirst": false}. With "useOnchain": true, it
+// measures new against tc.OnchainValue rather than current where that is
+// known, so that a run of answers each short of the threshold from the last
+// can't drift from what was reported. This matches
+// fluxmonitor.OutsideDeviation, except that the threshold is a fraction rather
+// than a percentage.
+func relativeThresholdFactory(params interface{}) (TriggerFn, error) {
+	fireOnFirst, params, err := relativeThresholdOption(params, "fireOnFirst", true)
+	if err != nil {
+		return nil, err
+	}
+	useOnchain, params, err := relativeThresholdOption(params, "useOnchain", false)
+	if err != nil {
+		return nil, err
+	}
+	t, parameter, err := exactNonNegative("relativeThreshold", params)
+	if err != nil {
+		return nil, err
+	}
+	bounds := newRelativeBounds(t)
+	tfn := thresholdTriggerFn{
+		factory:    "relativeThreshold",
+		parameter:  parameter,
+		threshold:  t,
+		triggering: bounds.atLeast,
+		measure:    relativeMeasure,
+	}
+	if !fireOnFirst {
+		tfn.quietOnFirst = true
+		tfn.triggering = func(current, new decimal.Decimal) bool {
+			return !current.IsZero() && bounds.atLeast(current, new)
+		}
+	}
+	tfn.useOnchain = useOnchain
+	if !fireOnFirst || useOnchain {
+		options := map[string]interface{}{"threshold": parameter}
+		if !fireOnFirst {
+			options["fireOnFirst"] = false
+		}
+		if useOnchain {
+			options["useOnchain"] = true
+		}
+		tfn.parameter = options
+	}
+	return tfn, nil
+}
+
+// relativeThresholdOption returns relativeThreshold's boolean option name,
+// or byDefault unless params set it, along with params without it.
+func relativeThresholdOption(params interface{}, name string, byDefault bool) (bool, interface{}, error) {
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return byDefault, params, nil
+	}
+	option, ok := m[name]
+	if !ok {
+		return byDefault, params, nil
+	}
+	value, ok := option.(bool)
+	if !ok {
+		return false, nil, ParamError{Factory: "relativeThreshold", Value: params, Field: name,
+			Err: errors.Errorf("relativeThreshold requires %s to be a boolean, got %v", name, option)}
+	}
+	rest := make(map[string]interface{}, len(m)-1)
+	for key, value := range m {
+		if key != name {
+			rest[key] = value
+		}
+	}
+	return value, rest, nil
+}
+
+// absoluteThresholdFactory returns a TriggerFn which fires when new differs
+// from current by at least the given amount. The amount may be given as a
+// decimal string, so that thresholds in wei are exact.
+func absoluteThresholdFactory(params interface{}) (TriggerFn, error) {
+	t, parameter, err := nonNegativeDecimal("absoluteThreshold", params)
+	if err != nil {
+		return nil, err
+	}
+	tfn := thresholdTriggerFn{
+		factory:   "absoluteThreshold",
+		parameter: parameter,
+		threshold: t,
+		triggering: func(current, new decimal.Decimal) bool {
+			return !AbsoluteDeviation(current, new).LessThan(t)
+		},
+		measure: absoluteMeasure,
+	}
+	if t.IsZero() {
+		tfn.warning = "absoluteThreshold of 0 fires on every answer"
+	}
+	return tfn, nil
+}
+
+// relativeDeviationAtLeast returns true if new differs from current by at
+// least threshold, as a fraction of current. A move away from a zero current
+// counts as a deviation of ZeroCurrentDeviation, as in RelativeDeviation.
+func relativeDeviationAtLeast(current, new, threshold decimal.Decimal) bool {
+	if current.IsZero() {
+		return !new.IsZero() && !ZeroCurrentDeviation.LessThan(threshold)
+	}
+	// Unlike RelativeDeviation, comparing against threshold*|current| is
+	// exact, with no rounding in Div.
+	return !AbsoluteDeviation(current, new).LessThan(threshold.Mul(current.Abs()))
+}
+
+// nonNegativeFloat returns params as a finite float no less than zero, or an
+// error naming factory. params may be a bare number or {"threshold": number}.
+func nonNegativeFloat(factory string, params interface{}) (float64, error) {
+	var p struct {
+		Threshold float64 `json:"threshold"`
+	}
+	if err := decodeParams(factory, params, &p); err != nil {
+		return 0, err
+	}
+	v := p.Threshold
+	if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+		return 0, ParamError{Factory: factory, Value: params, Field: "threshold",
+			Err: errors.Errorf("%s requires a finite, non-negative parameter, got %v", factory, v)}
+	}
+	return v, nil
+}
+
+// exactNonNegative is nonNegativeFloat, except that a threshold given as a
+// JSON number is read exactly from its decimal digits. It returns the
+// threshold along with its bare form: a float64 if that holds the threshold
+// exactly, or else the json.Number itself.
+func exactNonNegative(factory string, params interface{}) (decimal.Decimal, interface{}, error) {
+	v, err := nonNegativeFloat(factory, params)
+	if err != nil {
+		return decimal.Decimal{}, nil, err
+	}
+	number, ok := params.(json.Number)
+	if m, isObject := params.(map[string]interface{}); isObject {
+		number, ok = m["threshold"].(json.Number)
+	}
+	if !ok {
+		return decimal.NewFromFloat(v), v, nil
+	}
+	d, err := decimal.NewFromString(number.String())
+	if err != nil {
+		return decimal.Decimal{}, nil, errors.Wrapf(err, "%s has malformed params", factory)
+	}
+	if decimal.NewFromFloat(v).Equal(d) {
+		return d, v, nil
+	}
+	return d, number, nil
+}
+
+// nonNegativeDecimal is exactNonNegative, except that params may also be a
+// decimal string, or {"threshold": string}. A string is returned as its own
+// bare form.
+func nonNegativeDecimal(factory string, params interface{}) (decimal.Decimal, interface{}, error) {
+	if m, ok := params.(map[string]interface{}); ok && len(m) == 1 {
+		if s, ok := m["threshold"].(string); ok {
+			params = s
+		}
+	}
+	s, ok := params.(string)
+	if !ok {
+		return exactNonNegative(factory, params)
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, nil, ParamError{Factory: factory, Value: s, Field: "threshold",
+			Err: errors.Wrapf(err, "%s requires a decimal string, got %q", factory, s)}
+	}
+	if d.IsNegative() {
+		return decimal.Decimal{}, nil, ParamError{Factory: factory, Value: s, Field: "threshold",
+			Err: errors.Errorf("%s requires a finite, non-negative parameter, got %v", factory, s)}
+	}
+	return d, s, nil
+}
+
+// objectParams returns params as a JSON object, or an error naming factory.
+func objectParams(factory string, params interface{}) (map[string]interface{}, error) {
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("%s requires an object parameter, got %v", factory, params)
+	}
+	return m, nil
+}
@@ -0,0 +1,636 @@
+package triggerfns_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func mustRelativeThreshold(t *testing.T, threshold float64) triggerfns.TriggerFn {
+	t.Helper()
+	tfn, err := triggerfns.ExportedRelativeThresholdFactory(threshold)
+	require.NoError(t, err)
+	return tfn
+}
+
+func mustAbsoluteThreshold(t *testing.T, threshold float64) triggerfns.TriggerFn {
+	t.Helper()
+	tfn, err := triggerfns.ExportedAbsoluteThresholdFactory(threshold)
+	require.NoError(t, err)
+	return tfn
+}
+
+func TestTriggerFns_ValueScanRoundTrip(t *testing.T) {
+	original := triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.005),
+		mustAbsoluteThreshold(t, 0.01),
+	}
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 2)
+
+	assert.Equal(t, "absoluteThreshold", scanned[0].Factory())
+	assert.Equal(t, 0.01, scanned[0].Parameters())
+	assert.Equal(t, "relativeThreshold", scanned[1].Factory())
+	assert.Equal(t, 0.005, scanned[1].Parameters())
+}
+
+func TestTriggerFns_Value_Deterministic(t *testing.T) {
+	fns := triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.05),
+		mustAbsoluteThreshold(t, 0.01),
+		mustRelativeThreshold(t, 0.01),
+	}
+	reordered := triggerfns.TriggerFns{fns[2], fns[1], fns[0]}
+
+	first, err := fns.Value()
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		again, err := fns.Value()
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+	other, err := reordered.Value()
+	require.NoError(t, err)
+	assert.Equal(t, first, other)
+	assert.JSONEq(t, `{"version": 2, "functions": [
+		{"type": "absoluteThreshold", "params": 0.01},
+		{"type": "relativeThreshold", "params": 0.01},
+		{"type": "relativeThreshold", "params": 0.05}
+	]}`, string(first.([]byte)))
+}
+
+func TestTriggerFns_Value_Empty(t *testing.T) {
+	value, err := triggerfns.TriggerFns{}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":2,"functions":[]}`, string(value.([]byte)))
+}
+
+func TestTriggerFns_DuplicateFactoriesDoNotCollide(t *testing.T) {
+	value, err := triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.05),
+		mustRelativeThreshold(t, 0.01),
+	}.Value()
+	require.NoError(t, err)
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 2)
+	assert.Equal(t, 0.01, scanned[0].Parameters())
+	assert.Equal(t, 0.05, scanned[1].Parameters())
+}
+
+func TestTriggerFns_ValueScan_KeepsRepeatedRelativeThresholds(t *testing.T) {
+	value, err := triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.01),
+		mustRelativeThreshold(t, 0.05),
+	}.Value()
+	require.NoError(t, err)
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	require.Len(t, scanned, 2)
+
+	current, new := decimal.NewFromInt(100), decimal.NewFromInt(102)
+	for i, tc := range []struct {
+		threshold float64
+		fires     bool
+	}{{0.01, true}, {0.05, false}} {
+		assert.Equal(t, "relativeThreshold", scanned[i].Factory())
+		assert.Equal(t, tc.threshold, scanned[i].Parameters())
+		fired, err := scanned[i].Triggering(context.Background(), current, new, triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, tc.fires, fired, "threshold %v", tc.threshold)
+	}
+}
+
+func TestTriggerFns_Scan_AcceptsObjectAndArrayForms(t *testing.T) {
+	var fromObject, fromArray triggerfns.TriggerFns
+	require.NoError(t, fromObject.Scan(`{"relativeThreshold": 0.005, "absoluteThreshold": 0.01}`))
+	require.NoError(t, fromArray.Scan(`[
+		{"type": "relativeThreshold", "params": 0.005},
+		{"type": "absoluteThreshold", "params": 0.01}
+	]`))
+
+	objectValue, err := fromObject.Value()
+	require.NoError(t, err)
+	arrayValue, err := fromArray.Value()
+	require.NoError(t, err)
+	assert.Equal(t, objectValue, arrayValue)
+}
+
+func TestTriggerFns_ValueScan_StoredRows(t *testing.T) {
+	// Rows as they are stored in the database, with the exact bytes Value
+	// writes back for each. Changing these breaks existing jobs.
+	tests := []struct {
+		name, stored, value string
+	}{
+		{"object form",
+			`{"relativeThreshold": 0.005, "absoluteThreshold": 0.01}`,
+			`{"version":2,"functions":[{"type":"absoluteThreshold","params":0.01},{"type":"relativeThreshold","params":0.005}]}`},
+		{"array form",
+			`[{"type":"absoluteThreshold","params":0.01},{"type":"relativeThreshold","params":0.005}]`,
+			`{"version":2,"functions":[{"type":"absoluteThreshold","params":0.01},{"type":"relativeThreshold","params":0.005}]}`},
+		{"versioned form",
+			`{"version": 2, "functions": [{"type":"relativeThreshold","params":0.005},{"type":"absoluteThreshold","params":0.01}]}`,
+			`{"version":2,"functions":[{"type":"absoluteThreshold","params":0.01},{"type":"relativeThreshold","params":0.005}]}`},
+		{"cooldown",
+			`{"cooldown": {"period": "5m", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}}`,
+			`{"version":2,"functions":[{"type":"cooldown","params":{"period":"5m0s","triggerFn":{"type":"relativeThreshold","params":0.01}}}]}`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			for _, stored := range []interface{}{test.stored, []byte(test.stored)} {
+				var tfns triggerfns.TriggerFns
+				require.NoError(t, tfns.Scan(stored))
+				value, err := tfns.Value()
+				require.NoError(t, err)
+				assert.Equal(t, test.value, string(value.([]byte)))
+			}
+		})
+	}
+}
+
+func TestTriggerFns_Scan_UnsupportedType(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(42))
+}
+
+func TestTriggerFns_Scan_BadArrayEntries(t *testing.T) {
+	var scanned triggerfns.TriggerFns
+	assert.Error(t, scanned.Scan(`0.5`))
+	assert.Error(t, scanned.Scan(`[0.5]`))
+	assert.Error(t, scanned.Scan(`[{"params": 0.5}]`))
+	assert.Error(t, scanned.Scan(`[{"type": "absoluteThreshold"}]`))
+}
+
+func TestTriggerFns_Scan_ReportsEveryProblem(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []string
+	}{
+		{"array of non-objects", `[0.5, {"type": "relativeThreshold", "params": 0.01}, "staleness"]`,
+			[]string{"triggerFns[0]: must be a JSON object, got 0.5", "triggerFns[2]: must be a JSON object, got staleness"}},
+		{"number", `0.5`, []string{"TriggerFns must be a JSON array or object, got 0.5"}},
+		{"not JSON", []byte(`relativeThreshold`), []string{"TriggerFns must be valid JSON, got relativeThreshold"}},
+		{"unknown functions", `{"relativeThreshold": 0.01, "frobnicate": 1, "wibble": {}}`,
+			[]string{"triggerFns.frobnicate: unknown trigger function frobnicate", "triggerFns.wibble: unknown trigger function wibble"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var scanned triggerfns.TriggerFns
+			err := scanned.Scan(test.value)
+			require.Error(t, err)
+			errs := multierr.Errors(err)
+			require.Len(t, errs, len(test.expected))
+			for i, expected := range test.expected {
+				assert.Contains(t, errs[i].Error(), expected)
+			}
+			assert.Empty(t, scanned)
+		})
+	}
+}
+
+func TestTriggerFns_Scan_ReplacesExistingContents(t *testing.T) {
+	scanned := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.5)}
+
+	require.NoError(t, scanned.Scan(`{"absoluteThreshold": 2}`))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "absoluteThreshold", scanned[0].Factory())
+	assert.Equal(t, float64(2), scanned[0].Parameters())
+}
+
+func TestTriggerFns_Scan_LeavesReceiverUntouchedOnError(t *testing.T) {
+	scanned := triggerfns.TriggerFns{mustRelativeThreshold(t, 0.5)}
+
+	require.Error(t, scanned.Scan(`{"absoluteThreshold": 2, "relativeThreshold": "bad"}`))
+	require.Len(t, scanned, 1)
+	assert.Equal(t, "relativeThreshold", scanned[0].Factory())
+	assert.Equal(t, 0.5, scanned[0].Parameters())
+}
+
+func TestRelativeThreshold_AgreesWithOutsideDeviation(t *testing.T) {
+	tests := []struct {
+		name                string
+		curPrice, nextPrice decimal.Decimal
+		threshold           float64 // as a fraction
+	}{
+		{"0 current price", decimal.NewFromInt(0), decimal.NewFromInt(100), 0.02},
+		{"0 current and next price", decimal.NewFromInt(0), decimal.NewFromInt(0), 0.02},
+		{"inside deviation", decimal.NewFromInt(100), decimal.NewFromInt(101), 0.02},
+		{"equal to deviation", decimal.NewFromInt(100), decimal.NewFromInt(102), 0.02},
+		{"outside deviation", decimal.NewFromInt(100), decimal.NewFromInt(103), 0.02},
+		{"outside deviation zero", decimal.NewFromInt(100), decimal.NewFromInt(0), 0.02},
+		{"inside deviation, crosses 0 backwards", decimal.NewFromFloat(0.1), decimal.NewFromFloat(-0.1), 2.01},
+		{"equal to deviation, crosses 0 backwards", decimal.NewFromFloat(0.1), decimal.NewFromFloat(-0.1), 2},
+		{"outside deviation, crosses 0 forwards", decimal.NewFromFloat(-0.1), decimal.NewFromFloat(0.1), 1.99},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tfn := mustRelativeThreshold(t, test.threshold)
+			actual, err := tfn.Triggering(context.Background(), test.curPrice, test.nextPrice, triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			expected := fluxmonitor.OutsideDeviation(test.curPrice, test.nextPrice, test.threshold*100)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestAbsoluteThreshold_ExactWei(t *testing.T) {
+	const wei = "1234567890123456789"
+	original := triggerfns.TriggerFns{mustScanOne(t, `{"absoluteThreshold": "`+wei+`"}`)}
+	assert.Equal(t, wei, original[0].Parameters())
+
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "absoluteThreshold", "params": "`+wei+`"}]}`, string(value.([]byte)))
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, wei, scanned[0].Parameters())
+	assert.True(t, original.Equals(scanned))
+
+	current := decimal.RequireFromString("5000000000000000000")
+	for _, test := range []struct {
+		new      string
+		expected bool
+	}{
+		{"6234567890123456788", false},
+		{"6234567890123456789", true},
+	} {
+		fired, err := scanned[0].Triggering(context.Background(), current, decimal.RequireFromString(test.new), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, fired, test.new)
+	}
+}
+
+func TestAbsoluteThreshold_DecimalParams(t *testing.T) {
+	fromObject := mustScanOne(t, `{"absoluteThreshold": {"threshold": "0.5"}}`)
+	assert.Equal(t, "0.5", fromObject.Parameters())
+	assert.True(t, triggerfns.TriggerFns{fromObject}.Equals(triggerfns.TriggerFns{mustScanOne(t, `{"absoluteThreshold": 0.5}`)}))
+
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"absoluteThreshold": "1e18wei"}`))
+	assert.Error(t, tfns.Scan(`{"absoluteThreshold": "-1"}`))
+}
+
+func TestRelativeThreshold_ExactParams(t *testing.T) {
+	tenth := mustScanOne(t, `{"relativeThreshold": 0.1}`)
+	assert.Equal(t, 0.1, tenth.Parameters())
+	value, err := triggerfns.TriggerFns{tenth}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":2,"functions":[{"type":"relativeThreshold","params":0.1}]}`, string(value.([]byte)))
+
+	fired, err := tenth.Triggering(context.Background(), decimal.NewFromInt(1), decimal.RequireFromString("1.1"), triggerfns.TriggerContext{})
+	require.NoError(t, err)
+	assert.True(t, fired, "a move of exactly 0.1 should reach the threshold")
+
+	// More digits than a float64 holds are kept, and serialized as written.
+	const long = "0.12345678901234567891"
+	precise := mustScanOne(t, `{"relativeThreshold": {"threshold": `+long+`}}`)
+	assert.Equal(t, json.Number(long), precise.Parameters())
+	value, err = triggerfns.TriggerFns{precise}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":2,"functions":[{"type":"relativeThreshold","params":`+long+`}]}`, string(value.([]byte)))
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, triggerfns.TriggerFns{precise}.Equals(scanned))
+
+	for new, expected := range map[string]bool{"1.12345678901234567890": false, "1.12345678901234567891": true} {
+		fired, err := precise.Triggering(context.Background(), decimal.NewFromInt(1), decimal.RequireFromString(new), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, fired, new)
+	}
+}
+
+func TestMakeTriggerFn(t *testing.T) {
+	tests := []struct {
+		name       string
+		factory    string
+		params     interface{}
+		wantErr    string
+		wantParams interface{}
+	}{
+		{"unknown function", "squareDeviation", 0.5, "unknown trigger function squareDeviation", nil},
+		{"bad param type", "relativeThreshold", "0.5", "while constructing trigger function relativeThreshold", nil},
+		{"relativeThreshold", "relativeThreshold", 0.5, "", 0.5},
+		{"absoluteThreshold", "absoluteThreshold", float64(3), "", float64(3)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tfn, err := triggerfns.ExportedMakeTriggerFn(test.factory, test.params)
+			if test.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+				assert.Nil(t, tfn)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.factory, tfn.Factory())
+			assert.Equal(t, test.wantParams, tfn.Parameters())
+		})
+	}
+}
+
+func TestMakeTriggerFn_FactoryReturningNothing(t *testing.T) {
+	require.NoError(t, triggerfns.RegisterTriggerFn("nothing",
+		func(interface{}) (triggerfns.TriggerFn, error) { return nil, nil }))
+	defer triggerfns.ExportedUnregisterTriggerFn("nothing")
+
+	tfn, err := triggerfns.ExportedMakeTriggerFn("nothing", 0.5)
+	assert.EqualError(t, err, "factory for trigger function nothing returned no function")
+	assert.Nil(t, tfn)
+}
+
+func TestTriggerFns_JSONRoundTrip(t *testing.T) {
+	type spec struct {
+		TriggerFns triggerfns.TriggerFns `json:"triggerFns"`
+	}
+	original := spec{triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.05),
+		mustAbsoluteThreshold(t, 0.01),
+		mustRelativeThreshold(t, 0.01),
+	}}
+
+	b, err := json.Marshal(original)
+	require.NoError(t, err)
+	value, err := original.TriggerFns.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"triggerFns": `+string(value.([]byte))+`}`, string(b))
+
+	var decoded spec
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded.TriggerFns, 3)
+	for i, want := range []struct {
+		factory string
+		params  float64
+	}{{"absoluteThreshold", 0.01}, {"relativeThreshold", 0.01}, {"relativeThreshold", 0.05}} {
+		assert.Equal(t, want.factory, decoded.TriggerFns[i].Factory())
+		assert.Equal(t, want.params, decoded.TriggerFns[i].Parameters())
+	}
+}
+
+func TestTriggerFns_MarshalJSON_Empty(t *testing.T) {
+	for _, tfns := range []triggerfns.TriggerFns{nil, {}} {
+		b, err := json.Marshal(tfns)
+		require.NoError(t, err)
+		assert.Equal(t, `{"version":2,"functions":[]}`, string(b))
+	}
+}
+
+func TestTriggerFns_UnmarshalJSON(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, json.Unmarshal([]byte(`{"relativeThreshold": 0.01}`), &tfns))
+	require.Len(t, tfns, 1)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &tfns))
+	assert.Len(t, tfns, 1)
+
+	assert.Error(t, json.Unmarshal([]byte(`[{"type": "relativeThreshold", "params": -1}]`), &tfns))
+}
+
+func TestTriggerFns_Equals(t *testing.T) {
+	scan := func(value string) triggerfns.TriggerFns {
+		var tfns triggerfns.TriggerFns
+		require.NoError(t, tfns.Scan(value))
+		return tfns
+	}
+	base := triggerfns.TriggerFns{
+		mustRelativeThreshold(t, 0.01),
+		mustAbsoluteThreshold(t, 2),
+		mustRelativeThreshold(t, 0.05),
+	}
+
+	tests := []struct {
+		name  string
+		other triggerfns.TriggerFns
+		equal bool
+	}{
+		{"identical", base, true},
+		{"reordered", triggerfns.TriggerFns{base[2], base[1], base[0]}, true},
+		{"scanned", scan(`[
+			{"type": "relativeThreshold", "params": 0.050},
+			{"type": "absoluteThreshold", "params": 2.0},
+			{"type": "relativeThreshold", "params": 0.01}
+		]`), true},
+		{"differing threshold", triggerfns.TriggerFns{base[0], base[1], mustRelativeThreshold(t, 0.051)}, false},
+		{"differing type", triggerfns.TriggerFns{base[0], base[1], mustAbsoluteThreshold(t, 0.05)}, false},
+		{"missing function", triggerfns.TriggerFns{base[0], base[1]}, false},
+		{"repeated function", triggerfns.TriggerFns{base[0], base[1], base[0]}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.equal, base.Equals(test.other))
+			assert.Equal(t, test.equal, test.other.Equals(base))
+		})
+	}
+}
+
+func TestTriggerFns_Equals_Composites(t *testing.T) {
+	var a, b, c triggerfns.TriggerFns
+	require.NoError(t, a.Scan(`{"and": {"relativeThreshold": 0.01, "staleness": 60}}`))
+	require.NoError(t, b.Scan(`[{"type": "and", "params": {"staleness": 60, "relativeThreshold": 0.01}}]`))
+	require.NoError(t, c.Scan(`{"or": {"relativeThreshold": 0.01, "staleness": 60}}`))
+
+	assert.True(t, a.Equals(b))
+	assert.False(t, a.Equals(c))
+}
+
+func TestTriggerFns_String(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "relativeThreshold", "params": 0.005},
+		{"type": "absoluteThreshold", "params": 0.01},
+		{"type": "hysteresis", "params": {"upper": 0.02, "lower": 0.01}},
+		{"type": "or", "params": {"relativeThreshold": 0.05, "staleness": 3600}}
+	]`))
+
+	assert.Equal(t,
+		`absoluteThreshold(0.01), hysteresis({"lower":0.01,"upper":0.02}), `+
+			`or(relativeThreshold(0.05), staleness(3600)), relativeThreshold(0.005)`,
+		tfns.String())
+	assert.Equal(t, tfns.String(), fmt.Sprintf("%v", tfns))
+	assert.Equal(t, "", triggerfns.TriggerFns{}.String())
+}
+
+func TestNewTriggerFns(t *testing.T) {
+	tfns, err := triggerfns.NewTriggerFns(map[string]interface{}{
+		"relativeThreshold": 0.01,
+		"absoluteThreshold": "1000000000000000000",
+		"staleness":         60,
+		"hysteresis":        map[string]interface{}{"upper": 0.02, "lower": 0.01},
+		"not":               map[string]interface{}{"type": "relativeBps", "params": 25},
+	})
+	require.NoError(t, err)
+	require.Len(t, tfns, 5)
+
+	expected := []struct {
+		factory string
+		params  string
+	}{
+		{"absoluteThreshold", `"1000000000000000000"`},
+		{"hysteresis", `{"upper": 0.02, "lower": 0.01}`},
+		{"not", `{"type": "relativeBps", "params": 25}`},
+		{"relativeThreshold", `0.01`},
+		{"staleness", `60`},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.factory, tfns[i].Factory())
+		params, err := json.Marshal(tfns[i].Parameters())
+		require.NoError(t, err)
+		assert.JSONEq(t, e.params, string(params), e.factory)
+	}
+
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(`{"relativeThreshold": 0.01, "absoluteThreshold": "1000000000000000000",
+		"staleness": 60, "hysteresis": {"upper": 0.02, "lower": 0.01}, "not": {"type": "relativeBps", "params": 25}}`))
+	assert.True(t, tfns.Equals(scanned))
+}
+
+func TestNewTriggerFns_Errors(t *testing.T) {
+	_, err := triggerfns.NewTriggerFns(map[string]interface{}{
+		"relativeThreshold": -1,
+		"frobnicate":        1,
+		"staleness":         60,
+	})
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "triggerFns.frobnicate: unknown trigger function frobnicate")
+	assert.Contains(t, errs[1].Error(), "triggerFns.relativeThreshold")
+
+	_, err = triggerfns.NewTriggerFns(map[string]interface{}{"staleness": make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestRelativeThreshold_FireOnFirst(t *testing.T) {
+	zero := decimal.Zero
+	tests := []struct {
+		name       string
+		spec       string
+		wantParams string
+		wantString string
+		wantFired  bool
+	}{
+		{"default", `{"relativeThreshold": 0.01}`, `0.01`, "relativeThreshold(0.01)", true},
+		{"fireOnFirst true", `{"relativeThreshold": {"threshold": 0.01, "fireOnFirst": true}}`,
+			`0.01`, "relativeThreshold(0.01)", true},
+		{"fireOnFirst false", `{"relativeThreshold": {"threshold": 0.01, "fireOnFirst": false}}`,
+			`{"threshold": 0.01, "fireOnFirst": false}`, "relativeThreshold(0.01, fireOnFirst: false)", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			require.NoError(t, tfns.Scan(test.spec))
+			require.NoError(t, tfns.Validate())
+			assert.Equal(t, test.wantString, tfns.String())
+			params, err := json.Marshal(tfns[0].Parameters())
+			require.NoError(t, err)
+			assert.JSONEq(t, test.wantParams, string(params))
+
+			fired, err := tfns[0].Triggering(context.Background(), zero, decimal.NewFromInt(100), triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.Equal(t, test.wantFired, fired, "zero current")
+
+			// Once current is nonzero, the option makes no difference.
+			fired, err = tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.NewFromInt(102),
+				triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.True(t, fired)
+			fired, err = tfns[0].Triggering(context.Background(), decimal.NewFromInt(100), decimal.RequireFromString("100.5"),
+				triggerfns.TriggerContext{})
+			require.NoError(t, err)
+			assert.False(t, fired)
+
+			value, err := tfns.Value()
+			require.NoError(t, err)
+			var scanned triggerfns.TriggerFns
+			require.NoError(t, scanned.Scan(value))
+			assert.True(t, tfns.Equals(scanned))
+		})
+	}
+
+	var quiet, loud triggerfns.TriggerFns
+	require.NoError(t, quiet.Scan(`{"relativeThreshold": {"threshold": 0.01, "fireOnFirst": false}}`))
+	require.NoError(t, loud.Scan(`{"relativeThreshold": 0.01}`))
+	assert.False(t, quiet.Equals(loud))
+}
+
+func TestRelativeThreshold_FireOnFirst_BadParams(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	assert.Error(t, tfns.Scan(`{"relativeThreshold": {"threshold": 0.01, "fireOnFirst": "no"}}`))
+	assert.Error(t, tfns.Scan(`{"relativeThreshold": {"fireOnFirst": false}}`))
+	assert.Error(t, tfns.Scan(`{"absoluteThreshold": {"threshold": 0.01, "fireOnFirst": false}}`))
+}
+
+func TestRelativeThreshold_UseOnchain(t *testing.T) {
+	var offchain, onchain triggerfns.TriggerFns
+	require.NoError(t, offchain.Scan(`{"relativeThreshold": {"threshold": 0.01, "useOnchain": false}}`))
+	require.NoError(t, onchain.Scan(`{"relativeThreshold": {"threshold": 0.01, "useOnchain": true}}`))
+	assert.Equal(t, "relativeThreshold(0.01)", offchain.String())
+	assert.Equal(t, "relativeThreshold(0.01, useOnchain: true)", onchain.String())
+	assert.False(t, offchain.Equals(onchain))
+
+	// The last sample was 100.5, but 100 is what was reported.
+	tc := triggerfns.TriggerContext{OnchainValue: decimal.NewFromInt(100)}
+	for _, test := range []struct {
+		name                      string
+		current, new              string
+		tc                        triggerfns.TriggerContext
+		wantOffchain, wantOnchain bool
+	}{
+		{"drifted past threshold", "100.5", "101", tc, false, true},
+		{"drifted back", "100.5", "99.6", tc, false, false},
+		{"moved from sample only", "100.5", "99.4", tc, true, false},
+		{"on-chain value unknown", "100.5", "101", triggerfns.TriggerContext{}, false, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			current, new := decimal.RequireFromString(test.current), decimal.RequireFromString(test.new)
+			fired, err := offchain.ShouldReport(context.Background(), current, new, test.tc)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantOffchain, fired, "without useOnchain")
+			fired, err = onchain.ShouldReport(context.Background(), current, new, test.tc)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantOnchain, fired, "with useOnchain")
+
+			results, err := onchain.ShouldReportBatch(context.Background(), [][2]decimal.Decimal{{current, new}}, test.tc)
+			require.NoError(t, err)
+			assert.Equal(t, []bool{test.wantOnchain}, results, "batch")
+		})
+	}
+
+	result, err := triggerfns.TriggeringWithReason(context.Background(), onchain[0],
+		decimal.RequireFromString("100.5"), decimal.NewFromInt(101), tc)
+	require.NoError(t, err)
+	assert.Equal(t, "relative deviation 1.00% >= 1.00%", result.Reason)
+
+	value, err := onchain.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "relativeThreshold",
+		"params": {"threshold": 0.01, "useOnchain": true}}]}`, string(value.([]byte)))
+	var scanned triggerfns.TriggerFns
+	require.NoError(t, scanned.Scan(value))
+	assert.True(t, onchain.Equals(scanned))
+
+	assert.Error(t, scanned.Scan(`{"relativeThreshold": {"threshold": 0.01, "useOnchain": 1}}`))
+	require.NoError(t, scanned.Scan(`{"relativeThreshold": {"threshold": 0.01, "useOnchain": true, "fireOnFirst": false}}`))
+	assert.Equal(t, "relativeThreshold(0.01, fireOnFirst: false, useOnchain: true)", scanned.String())
+}
@@ -0,0 +1,63 @@
+package triggerfns
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// Warning is returned by Validate for parameters which are accepted, but are
+// unlikely to be what the job author intended.
+type Warning struct {
+	Path    string
+	Message string
+}
+
+func (w Warning) Error() string { return w.Path + ": " + w.Message }
+
+// IsWarning returns true if err is a Warning.
+func IsWarning(err error) bool {
+	_, ok := errors.Cause(err).(Warning)
+	return ok
+}
+
+// paramsWarner is implemented by trigger functions which can recognize
+// suspicious parameters. paramsWarning returns "" if there's nothing to report.
+type paramsWarner interface {
+	paramsWarning() string
+}
+
+// Validate re-runs each function's parameter checks, descending into
+// composites, and returns every problem found, including any functions
+// combined which conflict with each other. Each error names the path of the
+// offending function, e.g. "triggerFns[1].and.absoluteThreshold".
+// Problems which don't prevent the functions from running are Warnings; use
+// multierr.Errors and IsWarning to tell them apart.
+func (f TriggerFns) Validate() error {
+	var merr error
+	for i, tfn := range f {
+		merr = multierr.Append(merr, validateTriggerFn(fmt.Sprintf("triggerFns[%d]", i), tfn))
+	}
+	return multierr.Append(merr, checkConflicts("triggerFns", f))
+}
+
+func validateTriggerFn(path string, tfn TriggerFn) error {
+	tfn = unwrapMetered(tfn)
+	if c, ok := tfn.(compositeTriggerFn); ok {
+		var merr error
+		for _, inner := range c.fns {
+			merr = multierr.Append(merr, validateTriggerFn(path+"."+c.factory+"."+inner.Factory(), inner))
+		}
+		return merr
+	}
+	if _, err := makeTriggerFn(tfn.Factory(), tfn.Parameters()); err != nil {
+		return errors.Wrap(err, path)
+	}
+	if w, ok := tfn.(paramsWarner); ok {
+		if msg := w.paramsWarning(); msg != "" {
+			return Warning{Path: path, Message: msg}
+		}
+	}
+	return nil
+}
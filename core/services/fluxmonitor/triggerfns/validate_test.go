@@ -0,0 +1,91 @@
+package triggerfns_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestTriggerFns_Validate_Valid(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "relativeThreshold", "params": 0.005},
+		{"type": "absoluteThreshold", "params": 0.01},
+		{"type": "and", "params": {"relativeThreshold": 0, "staleness": 60}}
+	]`))
+	assert.NoError(t, tfns.Validate())
+}
+
+func TestTriggerFns_Scan_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{"negative relativeThreshold", `[{"type": "relativeThreshold", "params": -0.01}]`,
+			"triggerFns[0]: while constructing trigger function relativeThreshold: relativeThreshold requires a finite, non-negative parameter, got -0.01"},
+		{"negative absoluteThreshold", `[{"type": "absoluteThreshold", "params": -1}]`,
+			"triggerFns[0]: while constructing trigger function absoluteThreshold: absoluteThreshold requires a finite, non-negative parameter, got -1"},
+		{"non-float relativeThreshold", `[{"type": "relativeThreshold", "params": "1%"}]`,
+			"triggerFns[0]: while constructing trigger function relativeThreshold: relativeThreshold requires a number or an object parameter, got 1%"},
+		{"legacy object form", `{"relativeThreshold": -0.01}`,
+			"triggerFns.relativeThreshold: while constructing trigger function relativeThreshold: relativeThreshold requires a finite, non-negative parameter, got -0.01"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.EqualError(t, tfns.Scan(test.value), test.wantErr)
+		})
+	}
+}
+
+func TestTriggerFns_Scan_AggregatesErrors(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	err := tfns.Scan(`[
+		{"type": "relativeThreshold", "params": -0.01},
+		{"type": "absoluteThreshold", "params": 1},
+		{"type": "squareDeviation", "params": 4}
+	]`)
+	require.Error(t, err)
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "triggerFns[0]: ")
+	assert.Contains(t, errs[1].Error(), "triggerFns[2]: unknown trigger function squareDeviation")
+}
+
+func TestRelativeThresholdFactory_NonFinite(t *testing.T) {
+	for _, threshold := range []float64{math.NaN(), math.Inf(1)} {
+		_, err := triggerfns.ExportedRelativeThresholdFactory(threshold)
+		assert.Error(t, err, "threshold %v", threshold)
+	}
+}
+
+func TestTriggerFns_Validate_ZeroAbsoluteThresholdWarns(t *testing.T) {
+	var tfns triggerfns.TriggerFns
+	require.NoError(t, tfns.Scan(`[
+		{"type": "absoluteThreshold", "params": 0},
+		{"type": "or", "params": {"absoluteThreshold": 0, "relativeThreshold": 0.01}}
+	]`))
+
+	errs := multierr.Errors(tfns.Validate())
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		assert.True(t, triggerfns.IsWarning(err), "%v is not a warning", err)
+	}
+	assert.EqualError(t, errs[0], "triggerFns[0]: absoluteThreshold of 0 fires on every answer")
+	assert.EqualError(t, errs[1], "triggerFns[1].or.absoluteThreshold: absoluteThreshold of 0 fires on every answer")
+}
+
+func TestTriggerFns_Validate_UnregisteredFunction(t *testing.T) {
+	tfns := triggerfns.TriggerFns{squareDeviation{4}}
+	err := tfns.Validate()
+	require.Error(t, err)
+	assert.False(t, triggerfns.IsWarning(err))
+	assert.Contains(t, err.Error(), "triggerFns[0]: unknown trigger function squareDeviation")
+}
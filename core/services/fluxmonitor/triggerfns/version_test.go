@@ -0,0 +1,70 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerFns_Scan_Versions(t *testing.T) {
+	v1 := `{
+		"relativeThreshold": 0.005,
+		"cooldown": {"period": "5m", "triggerFn": {"type": "staleness", "params": 60}}
+	}`
+	v2 := `{"version": 2, "functions": [
+		{"type": "cooldown", "params": {"period": "5m0s", "triggerFn": {"type": "staleness", "params": 60}}},
+		{"type": "relativeThreshold", "params": 0.005}
+	]}`
+
+	var fromV1, fromV2 triggerfns.TriggerFns
+	require.NoError(t, fromV1.Scan(v1))
+	require.NoError(t, fromV2.Scan([]byte(v2)))
+	assert.True(t, fromV1.Equals(fromV2), "%v != %v", fromV1, fromV2)
+
+	// Both are written back as the latest version.
+	value, err := fromV1.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, v2, string(value.([]byte)))
+}
+
+func TestTriggerFns_Scan_VersionedEmpty(t *testing.T) {
+	for _, value := range []string{
+		`{"version": 2, "functions": []}`,
+		`{"version": 2, "functions": null}`,
+		`{"version": 2}`,
+	} {
+		tfns := triggerfns.TriggerFns{triggerfns.RelativeThreshold(0.01)}
+		require.NoError(t, tfns.Scan(value), value)
+		assert.Empty(t, tfns, value)
+	}
+}
+
+func TestTriggerFns_Scan_BadVersions(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{"newer version", `{"version": 3, "functions": []}`,
+			"unsupported TriggerFns version 3; this node reads up to version 2"},
+		{"version 1 envelope", `{"version": 1, "functions": []}`,
+			"unsupported TriggerFns version 1; this node reads up to version 2"},
+		{"string version", `{"version": "2", "functions": []}`,
+			"unsupported TriggerFns version 2; this node reads up to version 2"},
+		{"unknown field", `{"version": 2, "functions": [], "extra": true}`,
+			`TriggerFns version 2 has unknown field "extra"`},
+		{"functions not an array", `{"version": 2, "functions": {"relativeThreshold": 0.01}}`,
+			"TriggerFns functions must be a JSON array, got map[relativeThreshold:0.01]"},
+		{"bad entry", `{"version": 2, "functions": [{"params": 0.01}]}`,
+			"triggerFns.functions[0]: requires a string type, got <nil>"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.EqualError(t, tfns.Scan(test.value), test.err)
+		})
+	}
+}
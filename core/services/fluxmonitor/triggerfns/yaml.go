@@ -0,0 +1,80 @@
+package triggerfns
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// UnmarshalYAML accepts YAML in any of the forms Scan does, e.g.
+//
+//	relativeThreshold: 0.005
+//	absoluteThreshold: 0.01
+//
+// A null node leaves f unchanged, as with UnmarshalJSON.
+func (f *TriggerFns) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var spec interface{}
+	if err := unmarshal(&spec); err != nil {
+		return err
+	}
+	if spec == nil {
+		return nil
+	}
+	spec, err := stringKeys(spec)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return errors.Wrap(err, "while reading TriggerFns from YAML")
+	}
+	return f.Scan(b)
+}
+
+// MarshalYAML returns f in the versioned form written by Value, so that
+// UnmarshalYAML reads it back unchanged.
+func (f TriggerFns) MarshalYAML() (interface{}, error) {
+	value, err := f.Value()
+	if err != nil {
+		return nil, err
+	}
+	var entries interface{}
+	if err := json.Unmarshal(value.([]byte), &entries); err != nil {
+		return nil, errors.Wrap(err, "while writing TriggerFns as YAML")
+	}
+	return entries, nil
+}
+
+// stringKeys converts the map[interface{}]interface{} values YAML decodes
+// mappings to into the map[string]interface{} encoding/json expects.
+func stringKeys(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			s, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("TriggerFns keys must be strings, got %v", key)
+			}
+			converted, err := stringKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			m[s] = converted
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			converted, err := stringKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = converted
+		}
+		return s, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,75 @@
+package triggerfns_test
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTriggerFns_UnmarshalYAML(t *testing.T) {
+	var spec struct {
+		TriggerFns triggerfns.TriggerFns `yaml:"triggerFns"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+triggerFns:
+  relativeThreshold: 0.005
+  absoluteThreshold: 0.01
+`), &spec))
+
+	require.Len(t, spec.TriggerFns, 2)
+	assert.Equal(t, "absoluteThreshold", spec.TriggerFns[0].Factory())
+	assert.Equal(t, 0.01, spec.TriggerFns[0].Parameters())
+	assert.Equal(t, "relativeThreshold", spec.TriggerFns[1].Factory())
+	assert.Equal(t, 0.005, spec.TriggerFns[1].Parameters())
+}
+
+func TestTriggerFns_UnmarshalYAML_ArrayAndNested(t *testing.T) {
+	var fromYAML triggerfns.TriggerFns
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- type: cooldown
+  params:
+    period: 5m
+    triggerFn: {type: relativeThreshold, params: 0.01}
+- type: absoluteThreshold
+  params: "1000000000000000000"
+`), &fromYAML))
+
+	var fromJSON triggerfns.TriggerFns
+	require.NoError(t, fromJSON.Scan(`[
+		{"type": "cooldown", "params": {"period": "5m", "triggerFn": {"type": "relativeThreshold", "params": 0.01}}},
+		{"type": "absoluteThreshold", "params": "1000000000000000000"}
+	]`))
+	assert.Equal(t, fromJSON.String(), fromYAML.String())
+}
+
+func TestTriggerFns_YAMLRoundTrip(t *testing.T) {
+	var original triggerfns.TriggerFns
+	require.NoError(t, original.Scan(`{"relativeThreshold": 0.005, "absoluteThreshold": 0.01, "staleness": 60}`))
+
+	b, err := yaml.Marshal(original)
+	require.NoError(t, err)
+
+	var roundTripped triggerfns.TriggerFns
+	require.NoError(t, yaml.Unmarshal(b, &roundTripped))
+	originalValue, err := original.Value()
+	require.NoError(t, err)
+	roundTrippedValue, err := roundTripped.Value()
+	require.NoError(t, err)
+	assert.Equal(t, string(originalValue.([]byte)), string(roundTrippedValue.([]byte)))
+}
+
+func TestTriggerFns_UnmarshalYAML_Errors(t *testing.T) {
+	for _, doc := range []string{
+		"frobnicate: 1",
+		"relativeThreshold: -1",
+		"1: 0.01",
+		"relativeThreshold: [",
+	} {
+		var tfns triggerfns.TriggerFns
+		assert.Error(t, yaml.Unmarshal([]byte(doc), &tfns), doc)
+	}
+}
@@ -0,0 +1,76 @@
+package triggerfns
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// zscoreParams are the params of zscore, e.g. {"windowSize": 20, "sigma": 3}.
+type zscoreParams struct {
+	WindowSize int     `json:"windowSize"`
+	Sigma      float64 `json:"sigma"`
+}
+
+// zscoreTriggerFn fires when new lies more than sigma standard deviations
+// from the mean of the last windowSize answers it was shown. It records every
+// answer, fired on or not, and never fires until its window is full.
+type zscoreTriggerFn struct {
+	params zscoreParams
+
+	mu     sync.Mutex
+	window []float64 // ring buffer of the most recent answers
+	next   int       // index in window of the oldest answer, once it is full
+}
+
+func zscoreFactory(params interface{}) (TriggerFn, error) {
+	var p zscoreParams
+	if err := decodeParams("zscore", params, &p); err != nil {
+		return nil, err
+	}
+	if p.WindowSize < 2 {
+		return nil, errors.Errorf("zscore requires a windowSize of at least 2, got %d", p.WindowSize)
+	}
+	if p.Sigma <= 0 || math.IsInf(p.Sigma, 0) {
+		return nil, errors.Errorf("zscore requires a positive, finite sigma, got %v", p.Sigma)
+	}
+	return &zscoreTriggerFn{params: p}, nil
+}
+
+func (z *zscoreTriggerFn) Triggering(_ context.Context, _, new decimal.Decimal, _ TriggerContext) (bool, error) {
+	answer, _ := new.Float64()
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	fired := false
+	if len(z.window) == z.params.WindowSize {
+		mean, stddev := meanAndStddev(z.window)
+		fired = math.Abs(answer-mean) > z.params.Sigma*stddev
+		z.window[z.next] = answer
+		z.next = (z.next + 1) % len(z.window)
+	} else {
+		z.window = append(z.window, answer)
+	}
+	return fired, nil
+}
+
+// meanAndStddev returns the mean and population standard deviation of xs.
+func meanAndStddev(xs []float64) (mean, stddev float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		stddev += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(xs)))
+}
+
+// Clone returns a zscore function with z's params and an empty window.
+func (z *zscoreTriggerFn) Clone() TriggerFn { return &zscoreTriggerFn{params: z.params} }
+
+func (z *zscoreTriggerFn) stateful() bool          { return true }
+func (z *zscoreTriggerFn) Factory() string         { return "zscore" }
+func (z *zscoreTriggerFn) Parameters() interface{} { return z.params }
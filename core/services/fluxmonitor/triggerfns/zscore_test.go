@@ -0,0 +1,86 @@
+package triggerfns_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feed shows tfn each answer in turn against a fixed current of 100.
+func feed(t *testing.T, tfn triggerfns.TriggerFn, answers []float64) []bool {
+	t.Helper()
+	fired := make([]bool, len(answers))
+	for i, answer := range answers {
+		var err error
+		fired[i], err = tfn.Triggering(context.Background(), decimal.NewFromInt(100),
+			decimal.NewFromFloat(answer), triggerfns.TriggerContext{})
+		require.NoError(t, err)
+	}
+	return fired
+}
+
+func TestZScore_SpikeFiresJitterDoesNot(t *testing.T) {
+	tfn := mustScanOne(t, `[{"type": "zscore", "params": {"windowSize": 6, "sigma": 3}}]`)
+
+	stable := []float64{100, 100.1, 99.9, 100.05, 99.95, 100}
+	assert.Equal(t, make([]bool, len(stable)), feed(t, tfn, stable), "warming up")
+
+	jitter := []float64{100.1, 99.9, 100.08, 99.92}
+	assert.Equal(t, make([]bool, len(jitter)), feed(t, tfn, jitter), "jitter")
+
+	assert.Equal(t, []bool{true}, feed(t, tfn, []float64{105}), "spike")
+}
+
+func TestZScore_DoesNotFireWhileWarmingUp(t *testing.T) {
+	tfn := mustScanOne(t, `{"zscore": {"windowSize": 4, "sigma": 1}}`)
+	assert.Equal(t, []bool{false, false, false, false, true},
+		feed(t, tfn, []float64{100, 100, 100, 1000, 5000}))
+}
+
+func TestZScore_WindowSlides(t *testing.T) {
+	tfn := mustScanOne(t, `{"zscore": {"windowSize": 3, "sigma": 2}}`)
+	// Once the window holds only answers near 200, they set the mean and
+	// spread: 200.5 is ordinary, but 205 is an outlier.
+	assert.Equal(t, []bool{false, false, false, true, false, false, false, true},
+		feed(t, tfn, []float64{100, 101, 99, 200, 201, 199, 200.5, 205}))
+}
+
+func TestZScore_Clone(t *testing.T) {
+	original := triggerfns.TriggerFns{mustScanOne(t, `{"zscore": {"windowSize": 2, "sigma": 1}}`)}
+	feed(t, original[0], []float64{100, 101})
+
+	clone := original.Clone()
+	assert.Equal(t, []bool{false}, feed(t, clone[0], []float64{500}), "clone warms up afresh")
+	assert.Equal(t, []bool{true}, feed(t, original[0], []float64{500}))
+}
+
+func TestZScore_Parameters(t *testing.T) {
+	original := triggerfns.TriggerFns{mustScanOne(t, `{"zscore": {"windowSize": 20, "sigma": 2.5}}`)}
+	value, err := original.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": 2, "functions": [{"type": "zscore", "params": {"windowSize": 20, "sigma": 2.5}}]}`, string(value.([]byte)))
+}
+
+func TestZScore_BadParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"bare number", `{"zscore": 3}`},
+		{"missing sigma", `{"zscore": {"windowSize": 20}}`},
+		{"fractional windowSize", `{"zscore": {"windowSize": 2.5, "sigma": 3}}`},
+		{"windowSize too small", `{"zscore": {"windowSize": 1, "sigma": 3}}`},
+		{"zero sigma", `{"zscore": {"windowSize": 20, "sigma": 0}}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tfns triggerfns.TriggerFns
+			assert.Error(t, tfns.Scan(test.params))
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/smartcontractkit/chainlink/core/adapters"
 	"github.com/smartcontractkit/chainlink/core/assets"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/services/fluxmonitor/triggerfns"
 	"github.com/smartcontractkit/chainlink/core/store"
 	"github.com/smartcontractkit/chainlink/core/store/models"
 	"github.com/smartcontractkit/chainlink/core/store/orm"
@@ -17,6 +19,7 @@ import (
 	"github.com/asaskevich/govalidator"
 	"github.com/pkg/errors"
 	"github.com/tidwall/gjson"
+	"go.uber.org/multierr"
 )
 
 // ValidateJob checks the job and its associated Initiators and Tasks for any
@@ -145,10 +148,37 @@ func validateFluxMonitor(i models.Initiator, j models.JobSpec, store *store.Stor
 	if err := validateFeeds(i.Feeds, store); err != nil {
 		fe.Add(err.Error())
 	}
+	if i.TriggerFns.Exists() {
+		warnings, err := validateTriggerFns(i.TriggerFns)
+		for _, warning := range warnings {
+			logger.Warnw("Suspicious trigger function in flux monitor job", "job", j.ID, "warning", warning.Error())
+		}
+		for _, err := range multierr.Errors(err) {
+			fe.Add(err.Error())
+		}
+	}
 
 	return fe.CoerceEmptyToNil()
 }
 
+// validateTriggerFns reports every invalid trigger function in triggerFns.
+// Those which would only draw a warning are returned apart from the errors,
+// as they don't make the job invalid.
+func validateTriggerFns(triggerFns models.JSON) (warnings []error, err error) {
+	var tfns triggerfns.TriggerFns
+	if err := tfns.Scan(triggerFns.Bytes()); err != nil {
+		return nil, err
+	}
+	for _, e := range multierr.Errors(tfns.Validate()) {
+		if triggerfns.IsWarning(e) {
+			warnings = append(warnings, e)
+		} else {
+			err = multierr.Append(err, e)
+		}
+	}
+	return warnings, err
+}
+
 func validateFeeds(feeds models.Feeds, store *store.Store) error {
 	var feedsData []interface{}
 	if err := json.Unmarshal(feeds.Bytes(), &feedsData); err != nil {
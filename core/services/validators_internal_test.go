@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/core/store/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTriggerFns_SeparatesWarnings(t *testing.T) {
+	t.Parallel()
+
+	triggerFns, err := models.ParseJSON([]byte(`[{"type": "absoluteThreshold", "params": 0}]`))
+	require.NoError(t, err)
+	warnings, err := validateTriggerFns(triggerFns)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Error(), "absoluteThreshold of 0")
+
+	triggerFns, err = models.ParseJSON([]byte(
+		`[{"type": "absoluteThreshold", "params": 0}, {"type": "relativeThreshold", "params": -0.01}]`))
+	require.NoError(t, err)
+	warnings, err = validateTriggerFns(triggerFns)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "triggerFns[")
+	assert.NotContains(t, err.Error(), "absoluteThreshold of 0")
+	require.Len(t, warnings, 1)
+}
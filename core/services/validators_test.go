@@ -399,6 +399,39 @@ func TestValidateInitiator_FluxMonitorHappy(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestValidateInitiator_FluxMonitorWithTriggerFns(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	job := cltest.NewJob()
+	initiator := cltest.MustJSONSet(t, validInitiator, "params.triggerFns", []interface{}{
+		map[string]interface{}{"type": "relativeThreshold", "params": 0.01},
+		map[string]interface{}{"type": "relativeThreshold", "params": 0.05},
+	})
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(initiator), &initr))
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
+func TestValidateInitiator_FluxMonitorTriggerFnWarning(t *testing.T) {
+	t.Parallel()
+
+	store, cleanup := cltest.NewStore(t)
+	defer cleanup()
+
+	// An absoluteThreshold of 0 draws a warning, but doesn't reject the job.
+	job := cltest.NewJob()
+	initiator := cltest.MustJSONSet(t, validInitiator, "params.triggerFns",
+		[]interface{}{map[string]interface{}{"type": "absoluteThreshold", "params": 0}})
+	var initr models.Initiator
+	require.NoError(t, json.Unmarshal([]byte(initiator), &initr))
+	err := services.ValidateInitiator(initr, job, store)
+	require.NoError(t, err)
+}
+
 func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 	t.Parallel()
 
@@ -418,6 +451,8 @@ func TestValidateInitiator_FluxMonitorErrors(t *testing.T) {
 		{"pollingInterval", cltest.MustJSONDel(t, validInitiator, "params.pollingInterval")},
 		{"pollingInterval", cltest.MustJSONSet(t, validInitiator, "params.pollingInterval", "1s")},
 		{"idleThreshold", cltest.MustJSONSet(t, validInitiator, "params.idleThreshold", "30s")},
+		{"triggerFns[0]", cltest.MustJSONSet(t, validInitiator, "params.triggerFns",
+			[]interface{}{map[string]interface{}{"type": "relativeThreshold", "params": -0.01}})},
 	}
 	for _, test := range tests {
 		t.Run("bad "+test.Field, func(t *testing.T) {
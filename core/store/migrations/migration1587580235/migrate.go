@@ -0,0 +1,12 @@
+package migration1587580235
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// Migrate adds InitiatorParams.TriggerFns, the Flux Monitor's trigger functions.
+func Migrate(tx *gorm.DB) error {
+	return tx.Exec(`
+	  ALTER TABLE initiators ADD COLUMN "trigger_fns" text;
+	`).Error
+}
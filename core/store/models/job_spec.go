@@ -222,6 +222,7 @@ type InitiatorParams struct {
 	Threshold       float32  `json:"threshold,omitempty" gorm:"type:float"`
 	Precision       int32    `json:"precision,omitempty" gorm:"type:smallint"`
 	PollingInterval Duration `json:"pollingInterval,omitempty"`
+	TriggerFns      JSON     `json:"triggerFns,omitempty" gorm:"type:text"`
 }
 
 // defaults represents a default value for an initiator parameter. Value should
@@ -188,6 +188,12 @@ func (c Config) FeatureFluxMonitor() bool {
 	return c.viper.GetBool(EnvVarName("FeatureFluxMonitor"))
 }
 
+// FluxMonitorDefaultTriggerFns makes Flux Monitor jobs which list no trigger
+// functions use triggerfns.DefaultTriggerFns, instead of never reporting.
+func (c Config) FluxMonitorDefaultTriggerFns() bool {
+	return c.viper.GetBool(EnvVarName("FluxMonitorDefaultTriggerFns"))
+}
+
 // MaxRPCCallsPerSecond returns the rate at which RPC calls can be fired
 func (c Config) MaxRPCCallsPerSecond() uint64 {
 	return c.viper.GetUint64(EnvVarName("MaxRPCCallsPerSecond"))
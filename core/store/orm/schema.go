@@ -26,6 +26,7 @@ type ConfigSchema struct {
 	EnableExperimentalAdapters      bool            `env:"ENABLE_EXPERIMENTAL_ADAPTERS" default:"false"`
 	FeatureExternalInitiators       bool            `env:"FEATURE_EXTERNAL_INITIATORS" default:"false"`
 	FeatureFluxMonitor              bool            `env:"FEATURE_FLUX_MONITOR" default:"false"`
+	FluxMonitorDefaultTriggerFns    bool            `env:"FLUX_MONITOR_DEFAULT_TRIGGER_FNS" default:"false"`
 	MaximumServiceDuration          models.Duration `env:"MAXIMUM_SERVICE_DURATION" default:"8760h" `
 	MinimumServiceDuration          models.Duration `env:"MINIMUM_SERVICE_DURATION" default:"0s" `
 	EthGasBumpThreshold             uint64          `env:"ETH_GAS_BUMP_THRESHOLD" default:"12" `
@@ -14,6 +14,8 @@ type Afterer interface {
 	After(d time.Duration) <-chan time.Time
 }
 
+//go:generate mockery -name AfterNower -output ../internal/mocks/ -case=underscore
+
 // AfterNower is an interface that fulfills the `After()` and `Now()`
 // methods.
 type AfterNower interface {